@@ -0,0 +1,254 @@
+package arena
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"quoridor/engine"
+	"quoridor/game"
+)
+
+// Arena defaults
+const (
+	DefaultGames    = 100
+	DefaultMoveTime = 100 * time.Millisecond
+	DefaultMaxPlies = 400
+	// z is the quantile of the 95% confidence intervals
+	z = 1.96
+)
+
+// Config describes the match between the two engines
+type Config struct {
+	Games       int
+	BoardSizes  []int
+	Fences      int
+	MoveTime    time.Duration
+	MaxPlies    int
+	Concurrency int
+}
+
+// Result is the outcome of a game, the engines being numbered from 0
+type Result struct {
+	BoardSize int
+	Starter   int
+	// Winner is -1 for a draw, when the game reaches the maximum number of plies
+	Winner  int
+	Forfeit bool
+	Plies   int
+	Err     error
+	times   [2][]time.Duration
+}
+
+// Stats are the results and the timings of an engine
+type Stats struct {
+	Name      string
+	Wins      int
+	Losses    int
+	Draws     int
+	Forfeits  int
+	Score     float64
+	Low       float64
+	High      float64
+	Moves     int
+	TotalTime time.Duration
+	MaxTime   time.Duration
+}
+
+// Report is the summary of the match
+type Report struct {
+	Games         int
+	Engines       [2]Stats
+	AverageLength float64
+	Results       []Result
+}
+
+func (c Config) withDefaults() Config {
+	if c.Games <= 0 {
+		c.Games = DefaultGames
+	}
+	if len(c.BoardSizes) == 0 {
+		c.BoardSizes = []int{game.DefaultBoardSize}
+	}
+	if c.Fences <= 0 {
+		c.Fences = game.DefaultNumberOfFences
+	}
+	if c.MoveTime <= 0 {
+		c.MoveTime = DefaultMoveTime
+	}
+	if c.MaxPlies <= 0 {
+		c.MaxPlies = DefaultMaxPlies
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 1
+	}
+	return c
+}
+
+// Run play the games between the engines of the factories, alternating who starts on each board size
+func Run(factories [2]engine.Factory, config Config) (Report, error) {
+	config = config.withDefaults()
+	for _, size := range config.BoardSizes {
+		if _, err := game.NewBoard(size); err != nil {
+			return Report{}, err
+		}
+	}
+	results := make([]Result, config.Games)
+	names := [2]string{}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var startErr error
+	var mutex sync.Mutex
+	for worker := 0; worker < config.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			engines, err := start(factories)
+			if err != nil {
+				mutex.Lock()
+				startErr = err
+				mutex.Unlock()
+				for range indexes {
+				}
+				return
+			}
+			defer engines[0].Close()
+			defer engines[1].Close()
+			mutex.Lock()
+			names = [2]string{engines[0].Name(), engines[1].Name()}
+			mutex.Unlock()
+			for index := range indexes {
+				size := config.BoardSizes[(index/2)%len(config.BoardSizes)]
+				results[index] = play(engines, index%2, size, config)
+			}
+		}()
+	}
+	for index := 0; index < config.Games; index++ {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	if startErr != nil {
+		return Report{}, startErr
+	}
+	return summarize(names, results), nil
+}
+
+func start(factories [2]engine.Factory) ([2]engine.Engine, error) {
+	var engines [2]engine.Engine
+	for index, factory := range factories {
+		e, err := factory()
+		if err != nil {
+			if index == 1 {
+				engines[0].Close()
+			}
+			return engines, err
+		}
+		engines[index] = e
+	}
+	return engines, nil
+}
+
+// play a game, the engine failing to give a legal action in time losing by forfeit
+func play(engines [2]engine.Engine, starter int, size int, config Config) Result {
+	result := Result{BoardSize: size, Starter: starter, Winner: -1}
+	g, err := game.NewGameFromConfiguration(game.Configuration{BoardSize: size, NumberOfFencesPerPawnPlayer: config.Fences})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for result.Plies < config.MaxPlies && !g.Over {
+		// the first pawn is played by the starter
+		current := (starter + g.PawnTurn - 1) % 2
+		begin := time.Now()
+		action, err := engines[current].BestAction(g, config.MoveTime)
+		result.times[current] = append(result.times[current], time.Since(begin))
+		if err == nil {
+			g, err = g.Apply(action)
+		}
+		if err != nil {
+			result.Winner, result.Forfeit, result.Err = 1-current, true, err
+			return result
+		}
+		result.Plies++
+	}
+	if g.Over {
+		result.Winner = (starter + g.Winner() - 1) % 2
+	}
+	return result
+}
+
+func summarize(names [2]string, results []Result) Report {
+	report := Report{Games: len(results), Results: results}
+	totalPlies := 0
+	for index := range report.Engines {
+		report.Engines[index].Name = names[index]
+	}
+	for _, result := range results {
+		totalPlies += result.Plies
+		for index := range report.Engines {
+			stats := &report.Engines[index]
+			switch result.Winner {
+			case -1:
+				stats.Draws++
+			case index:
+				stats.Wins++
+			default:
+				stats.Losses++
+				if result.Forfeit {
+					stats.Forfeits++
+				}
+			}
+			for _, duration := range result.times[index] {
+				stats.Moves++
+				stats.TotalTime += duration
+				if duration > stats.MaxTime {
+					stats.MaxTime = duration
+				}
+			}
+		}
+	}
+	if len(results) > 0 {
+		report.AverageLength = float64(totalPlies) / float64(len(results))
+	}
+	for index := range report.Engines {
+		stats := &report.Engines[index]
+		points := float64(stats.Wins) + float64(stats.Draws)/2
+		stats.Score, stats.Low, stats.High = wilson(points, len(results))
+	}
+	return report
+}
+
+// wilson get the score and its Wilson score interval, draws counting as half a win
+func wilson(points float64, games int) (float64, float64, float64) {
+	if games == 0 {
+		return 0, 0, 0
+	}
+	n := float64(games)
+	p := points / n
+	center := (p + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return p, math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// AverageTime get the mean thinking time of the engine
+func (s Stats) AverageTime() time.Duration {
+	if s.Moves == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Moves)
+}
+
+func (r Report) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d games, %.1f plies on average\n", r.Games, r.AverageLength)
+	for _, stats := range r.Engines {
+		fmt.Fprintf(&builder, "%-12s score %5.1f%% [%5.1f%%, %5.1f%%]  +%d -%d =%d (%d forfeits)  %v/move, max %v\n",
+			stats.Name, stats.Score*100, stats.Low*100, stats.High*100,
+			stats.Wins, stats.Losses, stats.Draws, stats.Forfeits,
+			stats.AverageTime().Round(time.Microsecond), stats.MaxTime.Round(time.Microsecond))
+	}
+	return builder.String()
+}
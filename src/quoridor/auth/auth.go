@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"quoridor/exception"
+)
+
+// Token settings
+const (
+	TokenDuration             = 7 * 24 * time.Hour
+	SecretLength              = 32
+	SecretEnvironmentVariable = "QUORIDOR_TOKEN_SECRET"
+)
+
+// Claims are what a player token proves: the seat of a game until the expiry
+type Claims struct {
+	GameID    string `json:"gid"`
+	Number    int    `json:"num"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+var jwtHeader = header{"HS256", "JWT"}
+
+var secret = newSecret()
+
+func newSecret() []byte {
+	bytes := make([]byte, SecretLength)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+// Configure set the secret signing the tokens, an empty secret keeps the random one of the process
+func Configure(configuredSecret string) {
+	if configuredSecret != "" {
+		secret = []byte(configuredSecret)
+	}
+}
+
+// NewClaims get the claims of the seat, expiring after the token duration
+func NewClaims(gameID string, number int) Claims {
+	now := time.Now()
+	return Claims{gameID, number, now.Unix(), now.Add(TokenDuration).Unix()}
+}
+
+// Issue get a signed token for the seat of the game
+func Issue(gameID string, number int) (string, error) {
+	return Sign(NewClaims(gameID, number))
+}
+
+// Sign encode the claims as a JSON Web Token signed with HMAC-SHA256
+func Sign(claims Claims) (string, error) {
+	encodedHeader, err := encode(jwtHeader)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encode(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := encodedHeader + "." + encodedClaims
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature(unsigned)), nil
+}
+
+// Verify check the signature and the expiry of the token and get its claims
+func Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, invalidToken()
+	}
+	var h header
+	if err := decode(parts[0], &h); err != nil || h != jwtHeader {
+		return Claims{}, invalidToken()
+	}
+	signed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signed, signature(parts[0]+"."+parts[1])) {
+		return Claims{}, invalidToken()
+	}
+	var claims Claims
+	if err := decode(parts[1], &claims); err != nil {
+		return Claims{}, invalidToken()
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return Claims{}, exception.New(exception.UNAUTHORIZED, "The token has expired")
+	}
+	return claims, nil
+}
+
+func signature(unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func encode(value interface{}) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decode(part string, value interface{}) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, value)
+}
+
+func invalidToken() error {
+	return exception.New(exception.UNAUTHORIZED, "The token is invalid")
+}
@@ -0,0 +1,49 @@
+package boardimage
+
+// glyphs of a 3x5 pixel font for the labels of the PNG images, one string per row
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'a': {".#.", "#.#", "###", "#.#", "#.#"},
+	'b': {"##.", "#.#", "##.", "#.#", "##."},
+	'c': {".##", "#..", "#..", "#..", ".##"},
+	'd': {"##.", "#.#", "#.#", "#.#", "##."},
+	'e': {"###", "#..", "##.", "#..", "###"},
+	'f': {"###", "#..", "##.", "#..", "#.."},
+	'g': {".##", "#..", "#.#", "#.#", ".##"},
+	'h': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'i': {"###", ".#.", ".#.", ".#.", "###"},
+	'j': {"..#", "..#", "..#", "#.#", ".#."},
+	'k': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'l': {"#..", "#..", "#..", "#..", "###"},
+	'm': {"#.#", "###", "###", "#.#", "#.#"},
+	'n': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'o': {".#.", "#.#", "#.#", "#.#", ".#."},
+	'p': {"##.", "#.#", "##.", "#..", "#.."},
+	'q': {".#.", "#.#", "#.#", "###", ".##"},
+	'r': {"##.", "#.#", "##.", "#.#", "#.#"},
+	's': {".##", "#..", ".#.", "..#", "##."},
+	't': {"###", ".#.", ".#.", ".#.", ".#."},
+	'u': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'v': {"#.#", "#.#", "#.#", ".#.", ".#."},
+	'w': {"#.#", "#.#", "###", "###", "#.#"},
+	'x': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'z': {"###", "..#", ".#.", "#..", "###"},
+}
+
+// Sizes of the font, in pixels
+const (
+	glyphWidth   = 3
+	glyphHeight  = 5
+	glyphScale   = 2
+	glyphSpacing = 1
+)
@@ -0,0 +1,140 @@
+// Package boardimage draws the board of a game as an SVG or PNG image.
+package boardimage
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+// Sizes of the drawing, in pixels
+const (
+	SquareSize = 40
+	GapSize    = 10
+	Margin     = 30
+)
+
+// MaxBoardSize is the largest board drawn, the images growing as the square of the board size
+const MaxBoardSize = game.MaxBoardSize
+
+// Options select what the image shows besides the board, the fences and the pawns
+type Options struct {
+	LastMove bool
+}
+
+var (
+	backgroundColor = color.RGBA{0xe8, 0xd4, 0xb0, 0xff}
+	squareColor     = color.RGBA{0xb5, 0x83, 0x5a, 0xff}
+	blockedColor    = color.RGBA{0x55, 0x4b, 0x42, 0xff}
+	fenceColor      = color.RGBA{0x3b, 0x2a, 0x1a, 0xff}
+	labelColor      = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	lastMoveColor   = color.RGBA{0xf0, 0xc0, 0x20, 0xff}
+	outlineColor    = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	pawnColors      = []color.RGBA{
+		{0xd6, 0x27, 0x28, 0xff},
+		{0x1f, 0x77, 0xb4, 0xff},
+		{0x2c, 0xa0, 0x2c, 0xff},
+		{0x94, 0x67, 0xbd, 0xff},
+	}
+)
+
+// rect is a rectangle of the drawing
+type rect struct {
+	x, y, width, height int
+}
+
+// arrow goes from the center of a square to the edge of the pawn moved to another
+type arrow struct {
+	fromX, fromY, toX, toY int
+}
+
+// label is a text centered on a point
+type label struct {
+	text string
+	x, y int
+}
+
+// layout is the geometry of the drawing of a game, shared by the SVG and the PNG images
+type layout struct {
+	size        int
+	squares     []rect
+	blocked     []rect
+	fences      []rect
+	highlighted []rect
+	pawns       []rect
+	labels      []label
+	arrow       *arrow
+	radius      int
+}
+
+// checkSize tell whether the board is small enough to be drawn, before anything is allocated
+func checkSize(g game.Game) error {
+	if g.Board.BoardSize > MaxBoardSize {
+		return exception.New(exception.CONFLICT, fmt.Sprintf("Only the boards up to %v squares wide can be drawn", MaxBoardSize))
+	}
+	return nil
+}
+
+// imageSize get the width and the height of the drawing of the board, in pixels
+func imageSize(boardSize int) int {
+	return 2*Margin + boardSize*SquareSize + (boardSize-1)*GapSize
+}
+
+func square(position game.Position) rect {
+	return rect{Margin + position.Column*(SquareSize+GapSize), Margin + position.Row*(SquareSize+GapSize), SquareSize, SquareSize}
+}
+
+func center(position game.Position) (int, int) {
+	r := square(position)
+	return r.x + r.width/2, r.y + r.height/2
+}
+
+func fence(f game.Fence) rect {
+	r := square(f.NWSquare)
+	if f.Horizontal {
+		return rect{r.x, r.y + SquareSize, 2*SquareSize + GapSize, GapSize}
+	}
+	return rect{r.x + SquareSize, r.y, GapSize, 2*SquareSize + GapSize}
+}
+
+func newLayout(g game.Game, options Options) layout {
+	boardSize := g.Board.BoardSize
+	l := layout{size: imageSize(boardSize), radius: SquareSize * 7 / 20}
+	for row := 0; row < boardSize; row++ {
+		for column := 0; column < boardSize; column++ {
+			l.squares = append(l.squares, square(game.Position{column, row}))
+		}
+	}
+	for _, blockedSquare := range g.Board.BlockedSquares {
+		l.blocked = append(l.blocked, square(blockedSquare))
+	}
+	for _, f := range g.Fences {
+		l.fences = append(l.fences, fence(f))
+	}
+	for _, pawn := range g.Pawns {
+		l.pawns = append(l.pawns, square(pawn.Position))
+	}
+	for index := 0; index < boardSize; index++ {
+		x, y := center(game.Position{index, index})
+		l.labels = append(l.labels,
+			label{fmt.Sprintf("%c", 'a'+index), x, Margin / 2},
+			label{fmt.Sprint(index + 1), Margin / 2, y},
+		)
+	}
+	if played, found := g.LastAction(); options.LastMove && found {
+		if played.Action.Type == game.FENCE_ACTION {
+			l.highlighted = append(l.highlighted, fence(*played.Action.Fence))
+		} else if played.From != nil {
+			fromX, fromY := center(*played.From)
+			toX, toY := center(*played.Action.Position)
+			length := math.Hypot(float64(toX-fromX), float64(toY-fromY))
+			toX -= int(float64((toX-fromX)*l.radius) / length)
+			toY -= int(float64((toY-fromY)*l.radius) / length)
+			l.arrow = &arrow{fromX, fromY, toX, toY}
+		}
+	}
+	return l
+}
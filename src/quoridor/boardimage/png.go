@@ -0,0 +1,133 @@
+package boardimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"quoridor/game"
+)
+
+// PNGContentType is the media type of the PNG images
+const PNGContentType = "image/png"
+
+// PNG draw the board of the game as a PNG image, the boards larger than MaxBoardSize being rejected
+func PNG(g game.Game, options Options) ([]byte, error) {
+	if err := checkSize(g); err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, Draw(g, options)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Draw the board of the game on a new image, the size of the board being checked by the caller
+func Draw(g game.Game, options Options) *image.RGBA {
+	l := newLayout(g, options)
+	canvas := image.NewRGBA(image.Rect(0, 0, l.size, l.size))
+	fillRect(canvas, rect{0, 0, l.size, l.size}, backgroundColor)
+	for _, r := range l.squares {
+		fillRect(canvas, r, squareColor)
+	}
+	for _, r := range l.blocked {
+		fillRect(canvas, r, blockedColor)
+	}
+	for _, r := range l.fences {
+		fillRect(canvas, r, fenceColor)
+	}
+	for _, r := range l.highlighted {
+		fillRect(canvas, r, lastMoveColor)
+	}
+	for index, r := range l.pawns {
+		x, y := r.x+r.width/2, r.y+r.height/2
+		fillCircle(canvas, x, y, l.radius+2, outlineColor)
+		fillCircle(canvas, x, y, l.radius, pawnColors[index%len(pawnColors)])
+	}
+	if l.arrow != nil {
+		drawArrow(canvas, *l.arrow, lastMoveColor)
+	}
+	for _, text := range l.labels {
+		drawText(canvas, text, labelColor)
+	}
+	return canvas
+}
+
+func fillRect(canvas *image.RGBA, r rect, c color.RGBA) {
+	draw.Draw(canvas, image.Rect(r.x, r.y, r.x+r.width, r.y+r.height), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+func fillCircle(canvas *image.RGBA, x int, y int, radius int, c color.RGBA) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy <= radius*radius {
+				canvas.SetRGBA(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// drawArrow draw a thick line ending with a triangular head
+func drawArrow(canvas *image.RGBA, a arrow, c color.RGBA) {
+	dx, dy := float64(a.toX-a.fromX), float64(a.toY-a.fromY)
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	ux, uy := dx/length, dy/length
+	headLength, headWidth := 12.0, 9.0
+	for step := 0.0; step <= length-headLength; step++ {
+		fillCircle(canvas, a.fromX+int(ux*step), a.fromY+int(uy*step), 2, c)
+	}
+	baseX, baseY := float64(a.toX)-ux*headLength, float64(a.toY)-uy*headLength
+	fillTriangle(canvas,
+		[2]float64{float64(a.toX), float64(a.toY)},
+		[2]float64{baseX - uy*headWidth, baseY + ux*headWidth},
+		[2]float64{baseX + uy*headWidth, baseY - ux*headWidth},
+		c)
+}
+
+func fillTriangle(canvas *image.RGBA, a [2]float64, b [2]float64, c [2]float64, fill color.RGBA) {
+	minX := int(math.Floor(math.Min(a[0], math.Min(b[0], c[0]))))
+	maxX := int(math.Ceil(math.Max(a[0], math.Max(b[0], c[0]))))
+	minY := int(math.Floor(math.Min(a[1], math.Min(b[1], c[1]))))
+	maxY := int(math.Ceil(math.Max(a[1], math.Max(b[1], c[1]))))
+	side := func(p [2]float64, q [2]float64, x float64, y float64) float64 {
+		return (q[0]-p[0])*(y-p[1]) - (q[1]-p[1])*(x-p[0])
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px, py := float64(x), float64(y)
+			first, second, third := side(a, b, px, py), side(b, c, px, py), side(c, a, px, py)
+			if (first >= 0 && second >= 0 && third >= 0) || (first <= 0 && second <= 0 && third <= 0) {
+				canvas.SetRGBA(x, y, fill)
+			}
+		}
+	}
+}
+
+// drawText draw the label centered on its point with the pixel font, the runes without glyph being skipped
+func drawText(canvas *image.RGBA, text label, c color.RGBA) {
+	runes := []rune(text.text)
+	advance := (glyphWidth + glyphSpacing) * glyphScale
+	width := len(runes)*advance - glyphSpacing*glyphScale
+	left, top := text.x-width/2, text.y-glyphHeight*glyphScale/2
+	for index, r := range runes {
+		glyph, found := glyphs[r]
+		if !found {
+			continue
+		}
+		for row, pixels := range glyph {
+			for column, pixel := range pixels {
+				if pixel == '#' {
+					x, y := left+index*advance+column*glyphScale, top+row*glyphScale
+					fillRect(canvas, rect{x, y, glyphScale, glyphScale}, c)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package boardimage
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"strings"
+	"time"
+
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+// Media types of the replays
+const (
+	GIFContentType  = "image/gif"
+	HTMLContentType = "text/html"
+)
+
+// FinalFrameFactor is how many times longer the final position is shown
+const FinalFrameFactor = 3
+
+// Limits of the replays, each ply adding a frame
+const (
+	MaxReplayPlies = 1000
+	// MaxReplayPixels is the number of pixels of all the frames of a GIF, one byte each
+	MaxReplayPixels = 64 << 20
+	// MaxReplayBytes is the length of an HTML page
+	MaxReplayBytes = 16 << 20
+)
+
+// palette holds every color of the drawings, so that the frames are converted without loss
+var palette = color.Palette{backgroundColor, squareColor, blockedColor, fenceColor, labelColor, lastMoveColor, outlineColor,
+	pawnColors[0], pawnColors[1], pawnColors[2], pawnColors[3]}
+
+// checkReplay tell whether the positions are few and small enough to be exported, before anything is drawn
+func checkReplay(positions []game.Game) error {
+	if err := checkSize(positions[0]); err != nil {
+		return err
+	}
+	return CheckPlies(len(positions) - 1)
+}
+
+// CheckPlies tell whether a game of the number of plies can be exported, before its positions are replayed
+func CheckPlies(plies int) error {
+	if plies > MaxReplayPlies {
+		return exception.New(exception.CONFLICT, fmt.Sprintf("Only the replays up to %v plies can be exported", MaxReplayPlies))
+	}
+	return nil
+}
+
+func tooLarge() error {
+	return exception.New(exception.CONFLICT, "The replay is too large to be exported")
+}
+
+// GIF animate the positions, one frame per ply shown during the delay
+func GIF(positions []game.Game, delay time.Duration) ([]byte, error) {
+	if err := checkReplay(positions); err != nil {
+		return nil, err
+	}
+	size := imageSize(positions[0].Board.BoardSize)
+	if size*size*len(positions) > MaxReplayPixels {
+		return nil, tooLarge()
+	}
+	animation := &gif.GIF{}
+	for index, position := range positions {
+		drawn := Draw(position, Options{LastMove: true})
+		frame := image.NewPaletted(drawn.Bounds(), palette)
+		draw.Draw(frame, frame.Bounds(), drawn, image.Point{}, draw.Src)
+		hundredths := int(delay / (10 * time.Millisecond))
+		if index == len(positions)-1 {
+			hundredths *= FinalFrameFactor
+		}
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, hundredths)
+	}
+	var buffer bytes.Buffer
+	if err := gif.EncodeAll(&buffer, animation); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// HTML get a self-contained page playing the positions, one SVG frame per ply shown during the delay
+func HTML(positions []game.Game, delay time.Duration) ([]byte, error) {
+	if err := checkReplay(positions); err != nil {
+		return nil, err
+	}
+	var builder strings.Builder
+	title := "Replay of the game " + html.EscapeString(positions[0].ID)
+	fmt.Fprintf(&builder, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%v</title>
+<style>
+body { font-family: sans-serif; background: %v; text-align: center; }
+.controls { margin: 1em; }
+</style>
+</head>
+<body>
+<h1>%v</h1>
+`, title, hex(backgroundColor), title)
+	for index, position := range positions {
+		svg := string(SVG(position, Options{LastMove: true}))
+		svg = strings.Replace(svg, `id="head"`, fmt.Sprintf(`id="head-%d"`, index), 1)
+		svg = strings.Replace(svg, `url(#head)`, fmt.Sprintf(`url(#head-%d)`, index), 1)
+		hidden := " hidden"
+		if index == 0 {
+			hidden = ""
+		}
+		fmt.Fprintf(&builder, "<div class=\"frame\"%v>\n%v</div>\n", hidden, svg)
+		if builder.Len() > MaxReplayBytes {
+			return nil, tooLarge()
+		}
+	}
+	last := len(positions) - 1
+	fmt.Fprintf(&builder, `<div class="controls">
+<button id="previous">&#9664;</button>
+<button id="play">Pause</button>
+<button id="next">&#9654;</button>
+<input id="ply" type="range" min="0" max="%d" value="0">
+<span id="label"></span>
+</div>
+<script>
+var frames = document.querySelectorAll(".frame");
+var ply = 0;
+var playing = true;
+var delay = %d;
+function show(next) {
+	frames[ply].hidden = true;
+	ply = Math.max(0, Math.min(frames.length - 1, next));
+	frames[ply].hidden = false;
+	document.getElementById("ply").value = ply;
+	document.getElementById("label").textContent = "Ply " + ply + " / " + (frames.length - 1);
+}
+function tick() {
+	if (playing) {
+		show(ply < frames.length - 1 ? ply + 1 : 0);
+	}
+	setTimeout(tick, ply === frames.length - 1 ? delay * %d : delay);
+}
+document.getElementById("previous").onclick = function () { show(ply - 1); };
+document.getElementById("next").onclick = function () { show(ply + 1); };
+document.getElementById("ply").oninput = function (event) { show(Number(event.target.value)); };
+document.getElementById("play").onclick = function (event) {
+	playing = !playing;
+	event.target.textContent = playing ? "Pause" : "Play";
+};
+show(0);
+setTimeout(tick, delay);
+</script>
+</body>
+</html>
+`, last, delay/time.Millisecond, FinalFrameFactor)
+	return []byte(builder.String()), nil
+}
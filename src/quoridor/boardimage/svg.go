@@ -0,0 +1,55 @@
+package boardimage
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"quoridor/game"
+)
+
+// SVGContentType is the media type of the SVG images
+const SVGContentType = "image/svg+xml"
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func writeRect(builder *strings.Builder, r rect, fill color.RGBA) {
+	fmt.Fprintf(builder, `<rect x="%d" y="%d" width="%d" height="%d" fill="%v"/>`+"\n", r.x, r.y, r.width, r.height, hex(fill))
+}
+
+// SVG draw the board of the game as an SVG document
+func SVG(g game.Game, options Options) []byte {
+	l := newLayout(g, options)
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", l.size, l.size, l.size, l.size)
+	writeRect(&builder, rect{0, 0, l.size, l.size}, backgroundColor)
+	for _, r := range l.squares {
+		writeRect(&builder, r, squareColor)
+	}
+	for _, r := range l.blocked {
+		writeRect(&builder, r, blockedColor)
+	}
+	for _, r := range l.fences {
+		writeRect(&builder, r, fenceColor)
+	}
+	for _, r := range l.highlighted {
+		writeRect(&builder, r, lastMoveColor)
+	}
+	for index, r := range l.pawns {
+		fmt.Fprintf(&builder, `<circle cx="%d" cy="%d" r="%d" fill="%v" stroke="%v" stroke-width="2"/>`+"\n",
+			r.x+r.width/2, r.y+r.height/2, l.radius, hex(pawnColors[index%len(pawnColors)]), hex(outlineColor))
+	}
+	if l.arrow != nil {
+		fmt.Fprintf(&builder, `<defs><marker id="head" markerWidth="4" markerHeight="4" refX="2" refY="2" orient="auto"><path d="M0,0 L4,2 L0,4 z" fill="%v"/></marker></defs>`+"\n", hex(lastMoveColor))
+		fmt.Fprintf(&builder, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%v" stroke-width="5" marker-end="url(#head)"/>`+"\n",
+			l.arrow.fromX, l.arrow.fromY, l.arrow.toX, l.arrow.toY, hex(lastMoveColor))
+	}
+	for _, text := range l.labels {
+		fmt.Fprintf(&builder, `<text x="%d" y="%d" font-family="sans-serif" font-size="14" fill="%v" text-anchor="middle" dominant-baseline="central">%v</text>`+"\n",
+			text.x, text.y, hex(labelColor), text.text)
+	}
+	builder.WriteString("</svg>\n")
+	return []byte(builder.String())
+}
@@ -0,0 +1,301 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"quoridor/controller"
+	"quoridor/engine"
+	"quoridor/exception"
+	"quoridor/game"
+	"quoridor/openings"
+)
+
+// EnginesEnvironmentVariable lists the external engines offered as bots, as name=command separated by semicolons
+const EnginesEnvironmentVariable = "QUORIDOR_ENGINES"
+
+// CheckInterval is how often a bot waiting for its turn checks the game still exists
+const CheckInterval = time.Minute
+
+// MaxBots is the number of bots playing at once, each bot running at most one engine process
+const MaxBots = 64
+
+// ErrIdle is returned when nothing happened in the game of the bot for too long
+var ErrIdle = errors.New("the game has been idle for too long")
+
+// Config of a bot playing a seat, a zero IdleTimeout letting the bot wait forever
+type Config struct {
+	MoveTime    time.Duration
+	Restarts    int
+	IdleTimeout time.Duration
+}
+
+// DefaultConfig is the config of the bots added to the games
+var DefaultConfig = Config{time.Second, 1, 30 * time.Minute}
+
+// Bot is an engine seated at a game
+type Bot struct {
+	Engine string `json:"engine"`
+	URL    string `json:"url,omitempty"`
+	Number int    `json:"number"`
+}
+
+// Request is the bot to seat, either an engine offered by the server or the URL of a webhook bot
+type Request struct {
+	Engine string `json:"engine"`
+	URL    string `json:"url"`
+}
+
+var engines = map[string]engine.Factory{}
+var configuring sync.Mutex
+
+// playing is a bot added to a game, stopped by closing stop and done once it has returned
+type playing struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// bots are the bots playing, by game and by seat number, counted from the reservation of their seat
+var bots = make(map[string]map[int]*playing)
+var botCount int
+var running sync.Mutex
+
+// Configure offer the in-process engines, the greedy one playing the openings of the default book,
+// and the external engines of the specification, as name=command separated by semicolons
+func Configure(spec string) error {
+	configured := map[string]engine.Factory{}
+	configured[engine.RANDOM_ENGINE], _ = engine.NewFactory(engine.RANDOM_ENGINE)
+	configured[engine.GREEDY_ENGINE] = func() (engine.Engine, error) {
+		return engine.Greedy{openings.Default}, nil
+	}
+	for _, entry := range strings.Split(spec, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return fmt.Errorf("the engine %v is not of the form name=command", entry)
+		}
+		factory, err := engine.NewFactory(engine.ExecPrefix + parts[1])
+		if err != nil {
+			return err
+		}
+		configured[strings.TrimSpace(parts[0])] = factory
+	}
+	configuring.Lock()
+	engines = configured
+	configuring.Unlock()
+	return nil
+}
+
+// Engines get the names of the engines offered as bots
+func Engines() []string {
+	configuring.Lock()
+	defer configuring.Unlock()
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add seat a bot playing with the engine at the game
+func Add(gameID string, engineName string) (Bot, error) {
+	configuring.Lock()
+	factory, found := engines[engineName]
+	configuring.Unlock()
+	if !found {
+		return Bot{}, exception.New(exception.NOT_FOUND, "The engine does not exist")
+	}
+	number, err := seat(factory, gameID)
+	if err != nil {
+		return Bot{}, err
+	}
+	return Bot{engineName, "", number}, nil
+}
+
+// AddWebhook seat a bot running as an HTTP service at the game, the game being posted to the URL on each turn of the bot.
+// The host of the URL must be allowed and resolve to public addresses.
+func AddWebhook(gameID string, rawURL string) (Bot, error) {
+	webhook, err := NewWebhook(rawURL, DefaultWebhookConfig)
+	if err != nil {
+		return Bot{}, err
+	}
+	host := webhook.host()
+	if !isAllowedHost(host) {
+		return Bot{}, exception.New(exception.FORBIDDEN, "The webhook bots of this host are not allowed")
+	}
+	if !DefaultWebhookConfig.AllowPrivate {
+		if _, err := resolvePublic(context.Background(), host); err != nil {
+			return Bot{}, exception.New(exception.INVALID_REQUEST, "The host of the bot must resolve to public addresses")
+		}
+	}
+	number, err := seat(func() (engine.Engine, error) {
+		return webhook, nil
+	}, gameID)
+	if err != nil {
+		return Bot{}, err
+	}
+	return Bot{WEBHOOK_ENGINE, rawURL, number}, nil
+}
+
+// AddRequested seat the requested bot at the game on behalf of the user, only a logged in user adding webhook bots
+func AddRequested(gameID string, request Request, userID string) (Bot, error) {
+	if (request.Engine == "") == (request.URL == "") {
+		return Bot{}, exception.New(exception.INVALID_REQUEST, "Either the engine or the url of the bot is required")
+	}
+	if request.URL != "" {
+		if userID == "" {
+			return Bot{}, exception.New(exception.UNAUTHORIZED, "Log in to add a webhook bot")
+		}
+		return AddWebhook(gameID, request.URL)
+	}
+	return Add(gameID, request.Engine)
+}
+
+func seat(factory engine.Factory, gameID string) (int, error) {
+	running.Lock()
+	if botCount >= MaxBots {
+		running.Unlock()
+		return 0, exception.New(exception.CONFLICT, "Too many bots are playing, try again later")
+	}
+	botCount++
+	running.Unlock()
+	taken, err := gamecontroller.TakeSeat(gameID, "")
+	if err != nil {
+		release(gameID, 0, nil)
+		return 0, err
+	}
+	bot := &playing{make(chan struct{}), make(chan struct{})}
+	running.Lock()
+	if bots[gameID] == nil {
+		bots[gameID] = make(map[int]*playing)
+	}
+	bots[gameID][taken.Number] = bot
+	running.Unlock()
+	go func() {
+		defer release(gameID, taken.Number, bot)
+		PlaySeat(factory, gameID, taken.Number, DefaultConfig, bot.stop)
+	}()
+	return taken.Number, nil
+}
+
+// release free the place of the bot once it has returned
+func release(gameID string, number int, bot *playing) {
+	running.Lock()
+	defer running.Unlock()
+	botCount--
+	if bot == nil {
+		return
+	}
+	if bots[gameID][number] == bot {
+		delete(bots[gameID], number)
+		if len(bots[gameID]) == 0 {
+			delete(bots, gameID)
+		}
+	}
+	close(bot.done)
+}
+
+// Stop stop the bots added to the game and wait for them to return
+func Stop(gameID string) {
+	running.Lock()
+	stopped := bots[gameID]
+	delete(bots, gameID)
+	running.Unlock()
+	for _, bot := range stopped {
+		close(bot.stop)
+	}
+	for _, bot := range stopped {
+		<-bot.done
+	}
+}
+
+// PlaySeat play the seat of the game with a new engine of the factory, until the game is over or stop is closed.
+// The engine is only started once the game is playing. A crashed engine is restarted at most config.Restarts times,
+// the seat resigns when the engine cannot play or when nothing happened in the game for config.IdleTimeout.
+func PlaySeat(factory engine.Factory, gameID string, number int, config Config, stop <-chan struct{}) error {
+	updates, cancel := gamecontroller.Watch(gameID)
+	defer cancel()
+	var e engine.Engine
+	defer func() {
+		if e != nil {
+			e.Close()
+		}
+	}()
+	restarts := 0
+	interval := CheckInterval
+	if config.IdleTimeout > 0 && config.IdleTimeout < interval {
+		interval = config.IdleTimeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastChange := time.Now()
+	var lastStatus gamecontroller.Status
+	lastPlies := 0
+	for {
+		g, err := gamecontroller.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		if g.Over {
+			return nil
+		}
+		status, err := gamecontroller.GetStatus(gameID)
+		if err != nil {
+			return err
+		}
+		if status != lastStatus || len(g.History) != lastPlies {
+			lastChange, lastStatus, lastPlies = time.Now(), status, len(g.History)
+		}
+		if config.IdleTimeout > 0 && time.Since(lastChange) >= config.IdleTimeout {
+			gamecontroller.Resign(gameID, number)
+			return ErrIdle
+		}
+		if status == gamecontroller.PLAYING && g.PawnTurn == number {
+			if e == nil {
+				e, err = factory()
+				if err != nil {
+					e = nil
+					gamecontroller.Resign(gameID, number)
+					return err
+				}
+			}
+			action, err := e.BestAction(g, config.MoveTime)
+			if err == engine.ErrCrashed && restarts < config.Restarts {
+				restarts++
+				e.Close()
+				e = nil
+				continue
+			}
+			if err == nil {
+				err = play(gameID, action, number)
+			}
+			if err != nil {
+				gamecontroller.Resign(gameID, number)
+				return err
+			}
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-updates:
+		case <-ticker.C:
+		}
+	}
+}
+
+// play the action, the game having possibly changed while the engine was searching
+func play(gameID string, action game.Action, number int) error {
+	_, err := gamecontroller.PlayActionAs(gameID, action, number)
+	if exception.MatchGameError(err, exception.GAME_OVER) || exception.MatchGameError(err, exception.NOT_YOUR_TURN) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,226 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+// WEBHOOK_ENGINE is the engine name of the bots running as HTTP services
+const WEBHOOK_ENGINE = "webhook"
+
+// WebhookHostsEnvironmentVariable lists the hosts allowed to run webhook bots, separated by commas,
+// * allowing any public host. Webhook bots are disabled without hosts.
+const WebhookHostsEnvironmentVariable = "QUORIDOR_WEBHOOK_HOSTS"
+
+// AnyHost allows the webhook bots of any public host
+const AnyHost = "*"
+
+// MaxWebhookResponse is the number of bytes of the answer of a webhook bot read at most
+const MaxWebhookResponse = 64 << 10
+
+// ErrPrivateAddress is returned when the host of a webhook resolves to an address which is not public
+var ErrPrivateAddress = errors.New("the bot must be on a public address")
+
+// WebhookConfig is the policy of the calls to a webhook bot, the seat resigns once all the attempts have failed.
+// The calls only reach public addresses unless AllowPrivate is set.
+type WebhookConfig struct {
+	Timeout      time.Duration
+	Retries      int
+	RetryDelay   time.Duration
+	AllowPrivate bool
+}
+
+// DefaultWebhookConfig is the policy of the webhook bots added to the games
+var DefaultWebhookConfig = WebhookConfig{5 * time.Second, 2, 500 * time.Millisecond, false}
+
+// privateNetworks are the networks, besides the loopback, link-local, multicast and unspecified addresses,
+// a webhook cannot reach
+var privateNetworks = parseNetworks("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "198.18.0.0/15", "fc00::/7")
+
+var webhookHosts = map[string]bool{}
+var configuringWebhooks sync.Mutex
+
+// Turn is the body posted to a webhook bot when it has to play, the bot must answer with the action to play
+type Turn struct {
+	GameID       string        `json:"gameId"`
+	Number       int           `json:"number"`
+	MoveTime     int64         `json:"moveTime"`
+	Game         game.Game     `json:"game"`
+	LegalActions []game.Action `json:"legalActions"`
+}
+
+// Webhook is an engine posting the game to the URL of a bot and playing the action of its answer
+type Webhook struct {
+	url    string
+	config WebhookConfig
+	client *http.Client
+}
+
+// ConfigureWebhooks allow the webhook bots of the hosts, separated by commas, * allowing any public host
+func ConfigureWebhooks(spec string) {
+	hosts := map[string]bool{}
+	for _, host := range strings.Split(spec, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts[host] = true
+		}
+	}
+	configuringWebhooks.Lock()
+	webhookHosts = hosts
+	configuringWebhooks.Unlock()
+}
+
+// isAllowedHost check whether the webhook bots of the host can be added
+func isAllowedHost(host string) bool {
+	configuringWebhooks.Lock()
+	defer configuringWebhooks.Unlock()
+	return webhookHosts[AnyHost] || webhookHosts[strings.ToLower(host)]
+}
+
+// NewWebhook get the engine calling the bot at the URL, which must be an absolute http or https URL
+func NewWebhook(rawURL string, config WebhookConfig) (*Webhook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, exception.New(exception.INVALID_REQUEST, "The url of the bot must be an absolute http or https url")
+	}
+	client := &http.Client{Timeout: config.Timeout, CheckRedirect: refuseRedirect}
+	if !config.AllowPrivate {
+		client.Transport = &http.Transport{DialContext: publicDialer(config.Timeout)}
+	}
+	return &Webhook{rawURL, config, client}, nil
+}
+
+// refuseRedirect keep the webhook from sending the games to another host than the allowed one,
+// the redirection being answered as an invalid status
+func refuseRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// publicDialer get a dialer connecting to the resolved address of the host only when every address is public,
+// so that the host cannot resolve to another address between the check and the connection
+func publicDialer(timeout time.Duration) func(context.Context, string, string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	return func(ctx context.Context, network string, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := resolvePublic(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+	}
+}
+
+// resolvePublic get the addresses of the host, failing if any of them is not public
+func resolvePublic(ctx context.Context, host string) ([]net.IP, error) {
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := []net.IP{}
+	for _, address := range addresses {
+		if !isPublic(address.IP) {
+			return nil, ErrPrivateAddress
+		}
+		ips = append(ips, address.IP)
+	}
+	if len(ips) == 0 {
+		return nil, ErrPrivateAddress
+	}
+	return ips, nil
+}
+
+func isPublic(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// host get the host of the URL of the bot, without port
+func (w *Webhook) host() string {
+	parsed, _ := url.Parse(w.url)
+	return parsed.Hostname()
+}
+
+func (*Webhook) Name() string {
+	return WEBHOOK_ENGINE
+}
+
+// BestAction post the game to the bot, again after a network error or a server error
+func (w *Webhook) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	body, err := json.Marshal(Turn{g.ID, g.PawnTurn, int64(moveTime / time.Millisecond), g, g.LegalActions()})
+	if err != nil {
+		return game.Action{}, err
+	}
+	var action game.Action
+	retry := true
+	for attempt := 0; retry && attempt <= w.config.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(w.config.RetryDelay)
+		}
+		action, retry, err = w.post(body)
+		if err == nil {
+			return action, nil
+		}
+	}
+	return game.Action{}, err
+}
+
+// post the turn once, and tell whether a failure is worth retrying
+func (w *Webhook) post(body []byte) (game.Action, bool, error) {
+	response, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return game.Action{}, true, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusInternalServerError {
+		return game.Action{}, true, fmt.Errorf("the bot answered with the status %v", response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return game.Action{}, false, fmt.Errorf("the bot answered with the status %v", response.StatusCode)
+	}
+	var action game.Action
+	if err := json.NewDecoder(io.LimitReader(response.Body, MaxWebhookResponse)).Decode(&action); err != nil {
+		return game.Action{}, false, fmt.Errorf("the bot answered with an invalid action: %v", err)
+	}
+	if err := action.Validate(); err != nil {
+		return game.Action{}, false, err
+	}
+	return action, false, nil
+}
+
+func (*Webhook) Close() error {
+	return nil
+}
@@ -0,0 +1,191 @@
+// Package client is a client of the version 1 of the REST API.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"quoridor/game"
+)
+
+// Timeout of each request to the server
+const Timeout = 10 * time.Second
+
+// Game is a game as represented by the server
+type Game struct {
+	ID            string       `json:"id"`
+	Status        string       `json:"status"`
+	BoardSize     int          `json:"boardSize"`
+	CurrentPlayer int          `json:"currentPlayer"`
+	TurnActions   int          `json:"turnActions"`
+	Players       []Player     `json:"players"`
+	Fences        []game.Fence `json:"fences"`
+	Winner        int          `json:"winner"`
+}
+
+// Player is the pawn of a seat
+type Player struct {
+	Number     int           `json:"number"`
+	Seated     bool          `json:"seated"`
+	Position   game.Position `json:"position"`
+	Goal       string        `json:"goal"`
+	FencesLeft int           `json:"fencesLeft"`
+}
+
+// Seat is the seat taken by joining a game, with the token to play
+type Seat struct {
+	Number int    `json:"number"`
+	Token  string `json:"token"`
+}
+
+// Error is an error answered by the server
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Client calls the server at its base URL, such as http://localhost:8080
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+func New(baseURL string) *Client {
+	return &Client{strings.TrimRight(baseURL, "/"), &http.Client{Timeout: Timeout}}
+}
+
+// CreateGame create a game with the configuration
+func (c *Client) CreateGame(conf game.Configuration) (Game, error) {
+	if conf.BlockedSquares == nil {
+		conf.BlockedSquares = []game.Position{}
+	}
+	if conf.Walls == nil {
+		conf.Walls = []game.Fence{}
+	}
+	var g Game
+	err := c.do("POST", "/v1/games", "", conf, &g)
+	return g, err
+}
+
+// Join take a seat at the game
+func (c *Client) Join(gameID string) (Seat, error) {
+	var seat Seat
+	err := c.do("POST", "/v1/games/"+gameID+"/players", "", nil, &seat)
+	return seat, err
+}
+
+func (c *Client) GetGame(gameID string) (Game, error) {
+	var g Game
+	err := c.do("GET", "/v1/games/"+gameID, "", nil, &g)
+	return g, err
+}
+
+// GetBoard get the text board of the game drawn with the options
+func (c *Client) GetBoard(gameID string, options game.RenderOptions) (string, error) {
+	query := url.Values{}
+	for name, option := range map[string]bool{
+		"coordinates": options.Coordinates,
+		"legalMoves":  options.LegalMoves,
+		"lastMove":    options.LastMove,
+		"fencesLeft":  options.FencesLeft,
+		"ascii":       options.ASCII,
+		"color":       options.Color,
+	} {
+		if option {
+			query.Set(name, "true")
+		}
+	}
+	request, err := http.NewRequest("GET", c.baseURL+"/v1/games/"+gameID+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", "text/plain")
+	response, err := c.http.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		return "", readError(response)
+	}
+	board, err := ioutil.ReadAll(response.Body)
+	return string(board), err
+}
+
+// LegalActions get all the actions the current player can play, page after page
+func (c *Client) LegalActions(gameID string) ([]game.Action, error) {
+	actions := []game.Action{}
+	for page := 1; ; page++ {
+		var result struct {
+			Items []game.Action `json:"items"`
+			Total int           `json:"total"`
+		}
+		path := fmt.Sprintf("/v1/games/%v/legal-actions?page=%v&perPage=100", gameID, page)
+		if err := c.do("GET", path, "", nil, &result); err != nil {
+			return nil, err
+		}
+		actions = append(actions, result.Items...)
+		if len(result.Items) == 0 || len(actions) >= result.Total {
+			return actions, nil
+		}
+	}
+}
+
+// Play the action with the token of the seat
+func (c *Client) Play(gameID string, token string, action game.Action) (Game, error) {
+	var g Game
+	err := c.do("POST", "/v1/games/"+gameID+"/actions", token, action, &g)
+	return g, err
+}
+
+// do send the JSON body, if any, and decode the JSON answer into result
+func (c *Client) do(method string, path string, token string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := c.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		return readError(response)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func readError(response *http.Response) error {
+	apiError := &Error{Status: response.StatusCode}
+	if err := json.NewDecoder(response.Body).Decode(apiError); err != nil || apiError.Message == "" {
+		apiError.Message = response.Status
+	}
+	return apiError
+}
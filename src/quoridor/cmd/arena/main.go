@@ -0,0 +1,73 @@
+// Command arena plays games between two engines and reports their results.
+//
+//	arena -first greedy -second random -games 200 -sizes 5,7,9 -movetime 100ms
+//
+// An engine is random, greedy or exec: followed by the command of an external engine.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"quoridor/arena"
+	"quoridor/engine"
+)
+
+func main() {
+	first := flag.String("first", engine.GREEDY_ENGINE, "the first engine")
+	second := flag.String("second", engine.RANDOM_ENGINE, "the second engine")
+	games := flag.Int("games", arena.DefaultGames, "the number of games")
+	sizes := flag.String("sizes", "9", "the board sizes, separated by commas")
+	fences := flag.Int("fences", 0, "the number of fences per player, the default one if 0")
+	moveTime := flag.Duration("movetime", arena.DefaultMoveTime, "the thinking time of each move")
+	maxPlies := flag.Int("maxplies", arena.DefaultMaxPlies, "the number of plies after which a game is a draw")
+	concurrency := flag.Int("concurrency", 1, "the number of games played at the same time")
+	verbose := flag.Bool("verbose", false, "print the result of each game")
+	flag.Parse()
+
+	boardSizes, err := parseSizes(*sizes)
+	if err != nil {
+		exit(err)
+	}
+	var factories [2]engine.Factory
+	for index, spec := range []string{*first, *second} {
+		factories[index], err = engine.NewFactory(spec)
+		if err != nil {
+			exit(err)
+		}
+	}
+	report, err := arena.Run(factories, arena.Config{*games, boardSizes, *fences, *moveTime, *maxPlies, *concurrency})
+	if err != nil {
+		exit(err)
+	}
+	if *verbose {
+		for index, result := range report.Results {
+			fmt.Printf("game %d: size %d, engine %d starts, winner %d after %d plies", index+1, result.BoardSize, result.Starter, result.Winner, result.Plies)
+			if result.Err != nil {
+				fmt.Printf(" (%v)", result.Err)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Print(report)
+}
+
+func parseSizes(sizes string) ([]int, error) {
+	boardSizes := []int{}
+	for _, size := range strings.Split(sizes, ",") {
+		boardSize, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil {
+			return nil, fmt.Errorf("the board size %v is not a number", size)
+		}
+		boardSizes = append(boardSizes, boardSize)
+	}
+	return boardSizes, nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
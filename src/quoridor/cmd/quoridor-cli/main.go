@@ -0,0 +1,163 @@
+// Command quoridor-cli plays a game in the terminal against the REST API, creating a new game or joining one.
+//
+//	quoridor-cli -server http://localhost:8383 -size 9
+//	quoridor-cli -server http://localhost:8383 -game <gameId>
+//
+// Actions are typed in notation: e3 moves the pawn, e3h and e3v add a fence by its north west square.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"quoridor/client"
+	"quoridor/game"
+)
+
+const help = `Commands:
+  <square>       move the pawn, e.g. e3
+  <square>h|v    add a horizontal or vertical fence by its north west square, e.g. e3h
+  moves          list the legal moves
+  fences         list the legal fences
+  board          print the board again
+  help           print this help
+  quit           leave the game`
+
+// boardOptions draw the board with the coordinates of the notation and the legal moves
+var boardOptions = game.RenderOptions{Coordinates: true, LegalMoves: true, LastMove: true, FencesLeft: true}
+
+func main() {
+	server := flag.String("server", "http://localhost:8383", "the URL of the server")
+	gameID := flag.String("game", "", "the game to join, a new game is created if empty")
+	size := flag.Int("size", game.DefaultBoardSize, "the board size of a new game")
+	fences := flag.Int("fences", game.DefaultNumberOfFences, "the number of fences per player of a new game")
+	poll := flag.Duration("poll", time.Second, "how often to check whether the opponent has played")
+	flag.BoolVar(&boardOptions.ASCII, "ascii", false, "draw the board with ASCII characters only")
+	flag.BoolVar(&boardOptions.Color, "color", false, "color the board with ANSI escapes")
+	flag.Parse()
+
+	c := client.New(*server)
+	if *gameID == "" {
+		created, err := c.CreateGame(game.Configuration{BoardSize: *size, NumberOfFencesPerPawnPlayer: *fences})
+		if err != nil {
+			exit(err)
+		}
+		*gameID = created.ID
+		fmt.Printf("Game %v created, the opponent joins it with -game %v\n", created.ID, created.ID)
+	}
+	seat, err := c.Join(*gameID)
+	if err != nil {
+		exit(err)
+	}
+	fmt.Printf("You play the pawn %v, type help for the commands\n", seat.Number)
+	if err := play(c, *gameID, seat, *poll, bufio.NewScanner(os.Stdin)); err != nil {
+		exit(err)
+	}
+}
+
+// play until the game is over, waiting for the opponent between the turns of the player
+func play(c *client.Client, gameID string, seat client.Seat, poll time.Duration, input *bufio.Scanner) error {
+	waiting := ""
+	for {
+		g, err := c.GetGame(gameID)
+		if err != nil {
+			return err
+		}
+		if g.Status == "over" {
+			printBoard(c, g)
+			if g.Winner == seat.Number {
+				fmt.Println("You win!")
+			}
+			return nil
+		}
+		if g.Status != "playing" || g.CurrentPlayer != seat.Number {
+			message := "Waiting for the opponent to join"
+			if g.Status == "playing" {
+				message = "Waiting for the opponent to play"
+			}
+			if message != waiting {
+				if g.Status == "playing" {
+					printBoard(c, g)
+				}
+				fmt.Println(message)
+				waiting = message
+			}
+			time.Sleep(poll)
+			continue
+		}
+		waiting = ""
+		printBoard(c, g)
+		if quit := playTurn(c, g, seat, input); quit {
+			return nil
+		}
+	}
+}
+
+// playTurn read the commands of the player until an action is played, and tell whether the player quits
+func playTurn(c *client.Client, g client.Game, seat client.Seat, input *bufio.Scanner) bool {
+	for {
+		fmt.Printf("%v> ", seat.Number)
+		if !input.Scan() {
+			return true
+		}
+		command := strings.TrimSpace(input.Text())
+		switch command {
+		case "":
+		case "quit":
+			return true
+		case "help":
+			fmt.Println(help)
+		case "board":
+			printBoard(c, g)
+		case "moves", "fences":
+			actions, err := c.LegalActions(g.ID)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Println(listActions(actions, command == "moves"))
+		default:
+			action, err := game.ParseAction(command)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if _, err := c.Play(g.ID, seat.Token, action); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			return false
+		}
+	}
+}
+
+func listActions(actions []game.Action, moves bool) string {
+	notations := []string{}
+	for _, action := range actions {
+		if (action.Type == game.MOVE_ACTION) == moves {
+			notations = append(notations, action.Notation())
+		}
+	}
+	if len(notations) == 0 {
+		return "None"
+	}
+	return strings.Join(notations, " ")
+}
+
+func printBoard(c *client.Client, g client.Game) {
+	board, err := c.GetBoard(g.ID, boardOptions)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(board)
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
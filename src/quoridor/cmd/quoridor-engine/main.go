@@ -0,0 +1,32 @@
+// Command quoridor-engine is a reference external engine speaking the engine protocol on its standard
+// input and output, playing with one of the in-process engines.
+//
+//	quoridor-engine -engine greedy
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"quoridor/engine"
+)
+
+func main() {
+	spec := flag.String("engine", engine.GREEDY_ENGINE, "the in-process engine to play with: random or greedy")
+	flag.Parse()
+	if *spec != engine.RANDOM_ENGINE && *spec != engine.GREEDY_ENGINE {
+		fmt.Fprintf(os.Stderr, "the engine %v is not an in-process engine\n", *spec)
+		os.Exit(1)
+	}
+	factory, err := engine.NewFactory(*spec)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	e, _ := factory()
+	if err := engine.Serve(e, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
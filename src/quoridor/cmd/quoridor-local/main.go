@@ -0,0 +1,137 @@
+// Command quoridor-local plays a game in the terminal without the server, between two humans on one keyboard
+// or against a built-in engine.
+//
+//	quoridor-local -size 9 -second greedy -save game.json
+//	quoridor-local -load game.json
+//
+// The arrow keys select a square, f toggles between moving the pawn and adding a fence, r rotates the fence,
+// enter plays, s saves the game and q quits.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"quoridor/game"
+	"quoridor/local"
+)
+
+const clearScreen = "\x1b[H\x1b[2J"
+
+var arrows = map[byte]local.Key{'A': local.UP, 'B': local.DOWN, 'C': local.RIGHT, 'D': local.LEFT}
+
+func main() {
+	size := flag.Int("size", game.DefaultBoardSize, "the board size")
+	fences := flag.Int("fences", game.DefaultNumberOfFences, "the number of fences per player")
+	first := flag.String("first", local.HUMAN, "the player of the first pawn: human, random or greedy")
+	second := flag.String("second", local.HUMAN, "the player of the second pawn: human, random or greedy")
+	load := flag.String("load", "", "the file of a saved game to go on with")
+	save := flag.String("save", "", "the file to save the game to, the loaded file if empty")
+	flag.Parse()
+
+	var session *local.Session
+	var err error
+	if *load != "" {
+		session, err = local.Load(*load)
+		if *save == "" {
+			*save = *load
+		}
+	} else {
+		session, err = local.NewSession(game.Configuration{BoardSize: *size, NumberOfFencesPerPawnPlayer: *fences}, []string{*first, *second})
+	}
+	if err != nil {
+		exit(err)
+	}
+	restore, err := rawTerminal()
+	if err != nil {
+		exit(err)
+	}
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	go func() {
+		<-interrupted
+		restore()
+		os.Exit(1)
+	}()
+	err = run(local.NewUI(session, *save), bufio.NewReader(os.Stdin))
+	restore()
+	if err != nil {
+		exit(err)
+	}
+}
+
+func run(ui *local.UI, input *bufio.Reader) error {
+	for {
+		fmt.Print(clearScreen + ui.Render())
+		if ui.Session.IsBotTurn() {
+			if err := ui.PlayBots(); err != nil {
+				return err
+			}
+			continue
+		}
+		key, err := readKey(input)
+		if err != nil {
+			return err
+		}
+		if ui.Handle(key) {
+			return nil
+		}
+	}
+}
+
+// readKey read a key, the arrow keys being sent as escape sequences
+func readKey(input *bufio.Reader) (local.Key, error) {
+	b, err := input.ReadByte()
+	if err != nil {
+		return local.OTHER, err
+	}
+	switch b {
+	case '\x1b':
+		if next, _ := input.ReadByte(); next != '[' {
+			return local.OTHER, nil
+		}
+		arrow, err := input.ReadByte()
+		if err != nil {
+			return local.OTHER, err
+		}
+		if key, found := arrows[arrow]; found {
+			return key, nil
+		}
+		return local.OTHER, nil
+	case '\r', '\n', ' ':
+		return local.ENTER, nil
+	case 'f', 'F':
+		return local.FENCE_MODE, nil
+	case 'r', 'R':
+		return local.ROTATE, nil
+	case 's', 'S':
+		return local.SAVE, nil
+	case 'q', 'Q', '\x03', '\x04':
+		return local.QUIT, nil
+	}
+	return local.OTHER, nil
+}
+
+// rawTerminal read the keys as soon as they are pressed without echoing them, until restore is called
+func rawTerminal() (func(), error) {
+	stty := func(args ...string) error {
+		command := exec.Command("stty", args...)
+		command.Stdin = os.Stdin
+		return command.Run()
+	}
+	if err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("the terminal cannot read the keys: %v", err)
+	}
+	return func() {
+		stty("icanon", "echo")
+	}, nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
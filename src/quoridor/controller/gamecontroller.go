@@ -1,70 +1,254 @@
 package gamecontroller
 
 import (
-	"errors"
+	"fmt"
+	"sync"
+	"time"
 
+	"quoridor/exception"
 	"quoridor/game"
 	"quoridor/storage"
+
+	"github.com/lithammer/shortuuid"
 )
 
 type Player struct {
 	number int
-	fencesLeft int
+	userID string
+}
+
+// Seat describes a player seated at a game, without its token
+type Seat struct {
+	Number int `json:"number"`
+}
+
+// Status is the progress of a game
+type Status string
+
+const (
+	WAITING Status = "waiting"
+	PLAYING Status = "playing"
+	OVER    Status = "over"
+)
+
+// GameResult is the outcome of a finished game
+type GameResult struct {
+	GameID    string
+	BoardSize int
+	// Winner is the number of the winning player
+	Winner int
+	// Users are the identifiers of the users ordered by player number, empty for the anonymous players
+	Users []string
+}
+
+var gameOverListeners []func(GameResult)
+
+// OnGameOver register a listener called each time a game ends
+func OnGameOver(listener func(GameResult)) {
+	gameOverListeners = append(gameOverListeners, listener)
+}
+
+func notifyGameOver(result GameResult) {
+	for _, listener := range gameOverListeners {
+		listener(result)
+	}
+}
+
+// watchers get the states of the watched games, by game and by watch
+var watchers = make(map[string]map[int]chan game.Game)
+var lastWatch int
+var watching sync.Mutex
+
+// Watch get the state of the game after each action or new player, until the returned cancel function is called.
+// Only the latest state is kept for a slow watcher.
+func Watch(gameID string) (<-chan game.Game, func()) {
+	watching.Lock()
+	defer watching.Unlock()
+	lastWatch++
+	id := lastWatch
+	updates := make(chan game.Game, 1)
+	if watchers[gameID] == nil {
+		watchers[gameID] = make(map[int]chan game.Game)
+	}
+	watchers[gameID][id] = updates
+	return updates, func() {
+		watching.Lock()
+		defer watching.Unlock()
+		delete(watchers[gameID], id)
+		if len(watchers[gameID]) == 0 {
+			delete(watchers, gameID)
+		}
+	}
+}
+
+func notifyWatchers(g game.Game) {
+	watching.Lock()
+	defer watching.Unlock()
+	for _, updates := range watchers[g.ID] {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- g
+	}
 }
 
 type Party struct {
 	conf game.Configuration
 	game game.Game
 	players map[string]Player
+	createdAt time.Time
+	creator string
+	forkedFrom *Fork
+	// start is the position a forked game starts from, its history counting from there
+	start *game.Game
+	// lock is held by each change of the game from its find to its save, and shared by the copies of the party
+	lock *sync.Mutex
+}
+
+// Fork tells the game and the ply a game was forked from, the plies of the fork counting from this position
+type Fork struct {
+	GameID string `json:"gameId"`
+	Ply    int    `json:"ply"`
 }
 
 func (p Party) isReady() bool {
 	return len(p.players) == len(p.game.Pawns)
 }
 
+func (p Party) status() Status {
+	if p.game.Over {
+		return OVER
+	}
+	if !p.isReady() {
+		return WAITING
+	}
+	return PLAYING
+}
+
+func (p Party) seats() []Seat {
+	seats := make([]Seat, len(p.players))
+	for _, player := range p.players {
+		seats[player.number-1] = Seat{player.number}
+	}
+	return seats
+}
+
 func findPartyByGameID(id string) (Party, error) {
 	p, found := storage.Get(id)
 	if !found {
-		return Party{}, errors.New("The game does not exist")
+		return Party{}, exception.New(exception.NOT_FOUND, "The game does not exist")
 	}
 	return p.(Party), nil
 }
 
+// lockPartyByGameID find the party holding its lock, no other change of the game being saved until unlock is called
+func lockPartyByGameID(id string) (Party, func(), error) {
+	p, err := findPartyByGameID(id)
+	if err != nil {
+		return Party{}, nil, err
+	}
+	lock := p.lock
+	lock.Lock()
+	p, err = findPartyByGameID(id)
+	if err != nil {
+		lock.Unlock()
+		return Party{}, nil, err
+	}
+	return p, lock.Unlock, nil
+}
+
 func (p Party) checkPlayerCanPlay(playerToken string) error {
 	player, ok := p.getPlayer(playerToken)
 	if !ok {
-		return errors.New("Forbidden")
+		return exception.New(exception.FORBIDDEN, "Forbidden")
+	}
+	return p.checkSeatCanPlay(player.number)
+}
+
+func (p Party) checkSeatCanPlay(number int) error {
+	if number < 1 || number > len(p.players) {
+		return exception.New(exception.FORBIDDEN, "Forbidden")
 	}
 	if !p.isReady() {
-		return errors.New("Game is not ready")
+		return exception.New(exception.NOT_READY, "Game is not ready")
 	}
-	if player.number != p.game.PawnTurn {
-		return errors.New("It is not your turn")
+	if number != p.game.PawnTurn {
+		return exception.New(exception.NOT_YOUR_TURN, "It is not your turn")
 	}
 	return nil
 }
 
+func (p Party) result() GameResult {
+	users := make([]string, len(p.players))
+	for _, player := range p.players {
+		users[player.number-1] = player.userID
+	}
+	return GameResult{p.game.ID, p.game.Board.BoardSize, p.game.Winner(), users}
+}
+
 func (p Party) getPlayer(playerToken string) (Player, bool) {
 	player, found := p.players[playerToken]
 	return player, found
 }
 
+// getUserPlayer get the player of the user, anonymous players have no user
+func (p Party) getUserPlayer(userID string) (Player, bool) {
+	if userID == "" {
+		return Player{}, false
+	}
+	for _, player := range p.players {
+		if player.userID == userID {
+			return player, true
+		}
+	}
+	return Player{}, false
+}
+
+// savePlayer get the party with the player, the players of the other copies of the party being left untouched
 func (p Party) savePlayer(playerToken string, player Player) Party {
-	p.players[playerToken] = player
+	players := make(map[string]Player, len(p.players)+1)
+	for token, seated := range p.players {
+		players[token] = seated
+	}
+	players[playerToken] = player
+	p.players = players
 	return p
 }
 
 // CreateGame create a game with the default configuration
 func CreateGame(conf game.Configuration) (*game.Game, error) {
-	game, err := game.NewGame(conf.BoardSize)
+	return HostGame(conf, "")
+}
+
+// HostGame create a game on behalf of the creator, shown in the lobby
+func HostGame(conf game.Configuration, creator string) (*game.Game, error) {
+	ruleset, err := game.GetRuleset(conf.Variant)
+	if err != nil {
+		return nil, err
+	}
+	conf, err = ruleset.Configure(conf)
+	if err != nil {
+		return nil, err
+	}
+	game, err := game.NewGameFromConfiguration(conf)
 	if err != nil {
 		return nil, err
 	}
 	players := make(map[string]Player)
-	storage.Set(game.ID, Party{conf, game, players})
+	storage.Set(game.ID, Party{conf, game, players, time.Now(), creator, nil, nil, &sync.Mutex{}})
 	return &game, nil
 }
 
+// DeleteGame remove the game, for instance when its players could not be seated
+func DeleteGame(gameID string) error {
+	if _, err := findPartyByGameID(gameID); err != nil {
+		return err
+	}
+	storage.Delete(gameID)
+	return nil
+}
+
 // GetGame get the game via its identifier
 func GetGame(gameID string) (game.Game, error) {
 	p, err := findPartyByGameID(gameID)
@@ -74,119 +258,229 @@ func GetGame(gameID string) (game.Game, error) {
 	return p.game, nil
 }
 
-// JoinGame add a new player to the game
+// JoinGame add a new anonymous player to the game
 func JoinGame(gameID string, playerToken string) error {
-	p, err := findPartyByGameID(gameID)
+	return JoinGameAs(gameID, playerToken, "")
+}
+
+// JoinGameAs add a new player to the game, binding the seat to the user if any
+func JoinGameAs(gameID string, playerToken string, userID string) error {
+	_, err := joinGame(gameID, playerToken, userID)
+	return err
+}
+
+// TakeSeat add a new player to the game, the player proving its seat with a signed token instead of a stored one
+func TakeSeat(gameID string, userID string) (Seat, error) {
+	player, err := joinGame(gameID, shortuuid.New(), userID)
 	if err != nil {
-		return err
+		return Seat{}, err
 	}
+	return Seat{player.number}, nil
+}
+
+func joinGame(gameID string, playerToken string, userID string) (Player, error) {
+	p, unlock, err := lockPartyByGameID(gameID)
+	if err != nil {
+		return Player{}, err
+	}
+	defer unlock()
 	if p.isReady() {
-		return errors.New("Game is already set")
+		return Player{}, exception.New(exception.CONFLICT, "Game is already set")
+	}
+	if _, seated := p.getUserPlayer(userID); seated {
+		return Player{}, exception.New(exception.CONFLICT, "You already have a seat in this game")
 	}
-	newPlayer := Player{len(p.players) + 1, p.conf.NumberOfFencesPerPawnPlayer}
+	newPlayer := Player{len(p.players) + 1, userID}
 	p = p.savePlayer(playerToken, newPlayer)
 	storage.Set(p.game.ID, p)
-	return nil
+	notifyWatchers(p.game)
+	return newPlayer, nil
 }
 
-// AddFence add the fence on the board
-func AddFence(gameID string, fence game.Fence, playerToken string) (game.Game, error) {
-	p, err := findPartyByGameID(gameID)
+// PlayAction play the action, a pawn move or a fence addition, for the player
+func PlayAction(gameID string, action game.Action, playerToken string) (game.Game, error) {
+	return update(gameID, func(p Party) (game.Game, error) {
+		if err := p.checkPlayerCanPlay(playerToken); err != nil {
+			return game.Game{}, err
+		}
+		return p.game.Apply(action)
+	})
+}
+
+// PlayActionAs play the action for the player of the seat, whose token was verified by the caller
+func PlayActionAs(gameID string, action game.Action, number int) (game.Game, error) {
+	return update(gameID, func(p Party) (game.Game, error) {
+		if err := p.checkSeatCanPlay(number); err != nil {
+			return game.Game{}, err
+		}
+		return p.game.Apply(action)
+	})
+}
+
+// update save the change of the game and notify the watchers, then the listeners if the game has ended.
+// The change is made holding the lock of the game, so that only one change ends the game.
+func update(gameID string, change func(Party) (game.Game, error)) (game.Game, error) {
+	p, unlock, err := lockPartyByGameID(gameID)
 	if err != nil {
 		return game.Game{}, err
 	}
-	errPlayer := p.checkPlayerCanPlay(playerToken)
-	if errPlayer != nil {
-		return game.Game{}, errPlayer
-	}
-	player := p.players[playerToken]
-	if player.fencesLeft == 0 {
-		return game.Game{}, errors.New("No more fences to add")
-	}
-	g, errFence := p.game.AddFence(fence)
-	if errFence != nil {
-		return game.Game{}, errFence
+	wasOver := p.game.Over
+	g, err := change(p)
+	if err != nil {
+		unlock()
+		return game.Game{}, err
 	}
-	player.fencesLeft--
-	p = p.savePlayer(playerToken, player)
 	p.game = g
 	storage.Set(p.game.ID, p)
+	notifyWatchers(g)
+	unlock()
+	if g.Over && !wasOver {
+		notifyGameOver(p.result())
+	}
 	return g, nil
 }
 
-// GetFencePossibilities get all the possibiles places where to add a fence
-func GetFencePossibilities(gameID string) ([]game.Fence, error) {
-	g, err := GetGame(gameID)
+// Resign end the game for the player of the seat, whose token was verified by the caller
+func Resign(gameID string, number int) (game.Game, error) {
+	return update(gameID, func(p Party) (game.Game, error) {
+		if number < 1 || number > len(p.players) {
+			return game.Game{}, exception.New(exception.FORBIDDEN, "Forbidden")
+		}
+		return p.game.Resign(number)
+	})
+}
+
+// GetPositions get the positions of the game from its start, one after each action played
+func GetPositions(gameID string) ([]game.Game, error) {
+	p, err := findPartyByGameID(gameID)
 	if err != nil {
-		return []game.Fence{}, err
+		return nil, err
 	}
-	allPossibilities := addAllPossibilities(g.Board.BoardSize)
-	possibilities := removeFences(allPossibilities, g)
-	return possibilities, nil
+	var positions []game.Game
+	if p.start != nil {
+		positions, err = game.ReplayFrom(*p.start, p.game.History)
+	} else {
+		positions, err = game.Replay(p.conf, p.game.ID, p.game.History)
+	}
+	if err != nil {
+		return nil, err
+	}
+	positions[len(positions)-1] = p.game
+	return positions, nil
 }
 
-func addAllPossibilities(boardSize int) []game.Fence  {
-	numberOfIntersections := boardSize - 1;
-	actions := []game.Fence{}
-	for row := 0; row < numberOfIntersections; row++ {
-		for column := 0; column < numberOfIntersections; column++ {
-			actions = append(actions, game.Fence{game.Position{column, row}, true})
-			actions = append(actions, game.Fence{game.Position{column, row}, false})
-		}
+// GetGameAt get the game as it was after the number of plies
+func GetGameAt(gameID string, ply int) (game.Game, error) {
+	positions, err := GetPositions(gameID)
+	if err != nil {
+		return game.Game{}, err
 	}
-	return actions
-}
-
-func removeFences(allPossibilities []game.Fence, g game.Game) game.Fences {
-	var possibilities game.Fences
-	possibilities = append(allPossibilities[0:0], allPossibilities...)
-	var fences game.Fences
-	fences = g.Fences
-	for _, element := range allPossibilities {
-		if fences.IndexOf(element) > -1 {
-			possibilities = possibilities.RemoveFence(element)
-			positionSquare := game.NewPositionSquare(element.NWSquare)
-			if element.Horizontal {
-				rightFence := game.Fence{positionSquare.WestPosition, true}
-				possibilities = possibilities.RemoveFence(rightFence)
-				leftFence := game.Fence{positionSquare.EastPosition, true}
-				possibilities = possibilities.RemoveFence(leftFence)
-				oppositeFence := game.Fence{element.NWSquare, false}
-				possibilities = possibilities.RemoveFence(oppositeFence)
-			} else {
-				upFence := game.Fence{positionSquare.NorthPosition, false}
-				possibilities = possibilities.RemoveFence(upFence)
-				downFence := game.Fence{positionSquare.SouthPosition, false}
-				possibilities = possibilities.RemoveFence(downFence)
-				oppositeFence := game.Fence{element.NWSquare, true}
-				possibilities = possibilities.RemoveFence(oppositeFence)
-			}
-		} else {
-			if (!g.IsCrossable(element)) {
-				possibilities = possibilities.RemoveFence(element)
-			}
-		}
+	if ply < 0 || ply >= len(positions) {
+		return game.Game{}, exception.New(exception.NOT_FOUND, fmt.Sprintf("The game has no ply %v, only %v were played", ply, len(positions)-1))
 	}
-	return possibilities
+	return positions[ply], nil
 }
 
-// MovePawn move the pawn on the board
-func MovePawn(gameID string, destination game.Position, playerToken string) (game.Game, error) {
+// ForkGame create a new game starting from the position of the game after the number of plies, on behalf of the creator.
+// The fork has its own history, its ply 0 being the position it was forked from.
+func ForkGame(gameID string, ply int, creator string) (*game.Game, error) {
 	p, err := findPartyByGameID(gameID)
 	if err != nil {
-		return game.Game{}, err
+		return nil, err
 	}
-	errPlayer := p.checkPlayerCanPlay(playerToken)
-	if errPlayer != nil {
-		return game.Game{}, errPlayer
+	position, err := GetGameAt(gameID, ply)
+	if err != nil {
+		return nil, err
 	}
-	g, errPawn := p.game.MovePawn(destination)
-	if errPawn != nil {
-		return game.Game{}, errPawn
+	if position.Over {
+		return nil, exception.New(exception.GAME_OVER, "The game is over at this ply")
 	}
-	p.game = g
-	storage.Set(p.game.ID, p)
-	return g, nil
+	position.ID = shortuuid.New()
+	position.History = nil
+	start := position
+	storage.Set(position.ID, Party{p.conf, position, make(map[string]Player), time.Now(), creator, &Fork{gameID, ply}, &start, &sync.Mutex{}})
+	return &position, nil
+}
+
+// GetForkedFrom get the game and the ply the game was forked from, nil if it was not forked
+func GetForkedFrom(gameID string) (*Fork, error) {
+	p, err := findPartyByGameID(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return p.forkedFrom, nil
+}
+
+// GetStatus get the progress of the game
+func GetStatus(gameID string) (Status, error) {
+	p, err := findPartyByGameID(gameID)
+	if err != nil {
+		return "", err
+	}
+	return p.status(), nil
+}
+
+// GetSeats get the players seated at the game ordered by number
+func GetSeats(gameID string) ([]Seat, error) {
+	p, err := findPartyByGameID(gameID)
+	if err != nil {
+		return []Seat{}, err
+	}
+	return p.seats(), nil
+}
+
+// GetSeat get the seat of the player
+func GetSeat(gameID string, playerToken string) (Seat, error) {
+	p, err := findPartyByGameID(gameID)
+	if err != nil {
+		return Seat{}, err
+	}
+	player, found := p.getPlayer(playerToken)
+	if !found {
+		return Seat{}, exception.New(exception.FORBIDDEN, "Forbidden")
+	}
+	return Seat{player.number}, nil
+}
+
+// ResumeGame get the seat of the user, to play from another device
+func ResumeGame(gameID string, userID string) (Seat, error) {
+	p, err := findPartyByGameID(gameID)
+	if err != nil {
+		return Seat{}, err
+	}
+	player, seated := p.getUserPlayer(userID)
+	if !seated {
+		return Seat{}, exception.New(exception.FORBIDDEN, "You have no seat in this game")
+	}
+	return Seat{player.number}, nil
+}
+
+// GetLegalActions get all the actions the current player can play
+func GetLegalActions(gameID string) ([]game.Action, error) {
+	g, err := GetGame(gameID)
+	if err != nil {
+		return []game.Action{}, err
+	}
+	return g.LegalActions(), nil
+}
+
+// AddFence add the fence on the board
+func AddFence(gameID string, fence game.Fence, playerToken string) (game.Game, error) {
+	return PlayAction(gameID, game.NewFenceAction(fence), playerToken)
+}
+
+// GetFencePossibilities get all the possibiles places where to add a fence
+func GetFencePossibilities(gameID string) ([]game.Fence, error) {
+	g, err := GetGame(gameID)
+	if err != nil {
+		return []game.Fence{}, err
+	}
+	return g.GetPossibleFences(), nil
+}
+
+// MovePawn move the pawn on the board
+func MovePawn(gameID string, destination game.Position, playerToken string) (game.Game, error) {
+	return PlayAction(gameID, game.NewMoveAction(destination), playerToken)
 }
 
 func GetMovePossibilities(gameID string) ([]game.Position, error) {
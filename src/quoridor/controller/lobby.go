@@ -0,0 +1,81 @@
+package gamecontroller
+
+import (
+	"sort"
+	"time"
+
+	"quoridor/game"
+	"quoridor/storage"
+)
+
+// GameFilter selects the games listed in the lobby, a zero board size matches every board
+type GameFilter struct {
+	Status    Status
+	BoardSize int
+}
+
+// GameSummary describes a game in the lobby
+type GameSummary struct {
+	ID            string             `json:"id"`
+	Status        Status             `json:"status"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	Creator       string             `json:"creator"`
+	Configuration game.Configuration `json:"configuration"`
+	Players       int                `json:"players"`
+	ForkedFrom    *Fork              `json:"forkedFrom,omitempty"`
+}
+
+// PlayedGame is a game of the history of a user, with the seat of the user
+type PlayedGame struct {
+	GameSummary
+	Number int `json:"number"`
+}
+
+func (f GameFilter) matches(p Party) bool {
+	if f.Status != "" && f.Status != p.status() {
+		return false
+	}
+	return f.BoardSize == 0 || f.BoardSize == p.game.Board.BoardSize
+}
+
+func (p Party) summary() GameSummary {
+	return GameSummary{p.game.ID, p.status(), p.createdAt, p.creator, p.conf, len(p.players), p.forkedFrom}
+}
+
+// ListGames get the games matching the filter, the most recent first
+func ListGames(filter GameFilter) []GameSummary {
+	summaries := []GameSummary{}
+	for _, p := range listParties() {
+		if filter.matches(p) {
+			summaries = append(summaries, p.summary())
+		}
+	}
+	return summaries
+}
+
+// ListUserGames get the games where the user has a seat, the most recent first
+func ListUserGames(userID string) []PlayedGame {
+	games := []PlayedGame{}
+	for _, p := range listParties() {
+		if player, seated := p.getUserPlayer(userID); seated {
+			games = append(games, PlayedGame{p.summary(), player.number})
+		}
+	}
+	return games
+}
+
+func listParties() []Party {
+	parties := []Party{}
+	for _, value := range storage.List() {
+		if p, isParty := value.(Party); isParty {
+			parties = append(parties, p)
+		}
+	}
+	sort.SliceStable(parties, func(i, j int) bool {
+		if parties[i].createdAt.Equal(parties[j].createdAt) {
+			return parties[i].game.ID < parties[j].game.ID
+		}
+		return parties[i].createdAt.After(parties[j].createdAt)
+	})
+	return parties
+}
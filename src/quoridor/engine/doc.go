@@ -0,0 +1,31 @@
+/*
+Package engine defines the engines choosing the actions of a pawn, in process or as external programs.
+
+An external engine is a program reading commands on its standard input and writing replies on its standard
+output, one per line, in the spirit of the Universal Chess Interface. Unknown commands and replies are ignored,
+so that the protocol can grow.
+
+Squares use the algebraic notation: the column as a letter from a, the row as a number from 1, a1 being the
+north west square. A move is the destination square, such as e3. A fence is its north west square followed by
+h for a horizontal fence or v for a vertical one, such as e3h.
+
+The commands sent to the engine are:
+
+	quoridor
+		Sent once when the engine starts. The engine may reply "id name <name>", then must reply "quoridorok"
+		within 5 seconds.
+	position size <n> variant <variant> jump <rule> turn <pawn> actions <count> pawn <square> <goal> <fences> ... [blocked <square>] ... [fence <fence>] ...
+		The whole state of the game. The pawns are listed in order, the turn is the number of the pawn to play
+		from 1, actions is the number of actions already played during the turn, goal is north, east, south or
+		west, fences is the number of fences left to the pawn, and rule is official, edge-as-wall or no-diagonal.
+	go movetime <milliseconds>
+		Search the position, the engine must reply "bestmove <action>" within the move time. The engine may
+		write "info ..." lines while searching. Every go gets its bestmove reply, even a late one, in order:
+		the late replies are skipped when the engine is asked to search again. An engine with no position to
+		search or whose search fails replies "bestmove none", and loses the game.
+	quit
+		The engine must exit.
+
+An engine answering late or crashing loses the game, just as an engine playing an illegal action.
+*/
+package engine
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"quoridor/game"
+)
+
+// Engine specifications
+const (
+	RANDOM_ENGINE = "random"
+	GREEDY_ENGINE = "greedy"
+	ExecPrefix    = "exec:"
+)
+
+// Engine chooses the action to play for the current pawn
+type Engine interface {
+	Name() string
+	BestAction(g game.Game, moveTime time.Duration) (game.Action, error)
+	Close() error
+}
+
+// Factory create a new instance of an engine, each game played at the same time needing its own
+type Factory func() (Engine, error)
+
+// NewFactory get the factory of the specification: random, greedy or exec: followed by the command of an external engine
+func NewFactory(spec string) (Factory, error) {
+	switch {
+	case spec == RANDOM_ENGINE:
+		var seed int64
+		return func() (Engine, error) {
+			return NewRandom(time.Now().UnixNano() + atomic.AddInt64(&seed, 1)), nil
+		}, nil
+	case spec == GREEDY_ENGINE:
+		return func() (Engine, error) {
+			return Greedy{}, nil
+		}, nil
+	case strings.HasPrefix(spec, ExecPrefix):
+		command := strings.Fields(strings.TrimPrefix(spec, ExecPrefix))
+		if len(command) == 0 {
+			return nil, fmt.Errorf("the command of the engine is missing")
+		}
+		return func() (Engine, error) {
+			return StartProcess(command)
+		}, nil
+	}
+	return nil, fmt.Errorf("the engine %v does not exist, use %v, %v or %v<command>", spec, RANDOM_ENGINE, GREEDY_ENGINE, ExecPrefix)
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"math"
+	"time"
+
+	"quoridor/game"
+	"quoridor/openings"
+)
+
+// Greedy plays the action leaving the best race to the goal lines, looking one action ahead.
+// A fence slowing down the opponent as much as a move speeds up the pawn is not played.
+// In the opening, the move of the book with the best results is played instead of searching,
+// and once no pawn has fences left, the move of the solved race.
+type Greedy struct {
+	// Book is the opening book consulted first, none when nil
+	Book *openings.Book
+}
+
+func (Greedy) Name() string {
+	return GREEDY_ENGINE
+}
+
+func (e Greedy) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	if e.Book != nil {
+		if action, found := e.Book.Suggest(g); found {
+			return action, nil
+		}
+	}
+	if solution, err := g.Solve(); err == nil && solution.Action != nil {
+		return *solution.Action, nil
+	}
+	number := g.PawnTurn
+	var best game.Action
+	bestScore := math.MinInt32
+	for _, action := range g.LegalActions() {
+		next, err := g.Apply(action)
+		if err != nil {
+			continue
+		}
+		if next.Over && next.Winner() == number {
+			return action, nil
+		}
+		score := Evaluate(next, number)
+		if score > bestScore {
+			best, bestScore = action, score
+		}
+	}
+	if bestScore == math.MinInt32 {
+		return game.Action{}, ErrNoLegalAction
+	}
+	return best, nil
+}
+
+func (Greedy) Close() error {
+	return nil
+}
+
+// Evaluate get how far ahead the pawn is in the race to the goal lines
+func Evaluate(g game.Game, number int) int {
+	score := 0
+	for index := range g.Pawns {
+		if index+1 == number {
+			score -= g.DistanceToGoal(index + 1)
+		} else {
+			score += g.DistanceToGoal(index + 1)
+		}
+	}
+	return score
+}
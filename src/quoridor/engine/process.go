@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"quoridor/game"
+)
+
+// Delays granted to an external engine
+const (
+	HandshakeTimeout = 5 * time.Second
+	MoveMargin       = 500 * time.Millisecond
+	QuitTimeout      = time.Second
+)
+
+// Engine failures
+var (
+	ErrNoLegalAction = errors.New("no legal action")
+	ErrTimeout       = errors.New("the engine did not answer in time")
+	ErrCrashed       = errors.New("the engine has stopped")
+	ErrNoMove        = errors.New("the engine found no move")
+)
+
+// Process is an external engine speaking the line-based protocol on its standard input and output
+type Process struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+	// late is the number of searches which timed out, whose best moves are still to come and must be skipped
+	late int
+}
+
+// StartProcess run the command and wait for the engine to be ready
+func StartProcess(command []string) (*Process, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	p := &Process{command[0], cmd, stdin, make(chan string), 0}
+	go p.read(stdout)
+	if err := p.handshake(); err != nil {
+		p.kill()
+		return nil, err
+	}
+	return p, nil
+}
+
+// read forward the lines of the engine until it stops
+func (p *Process) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		p.lines <- strings.TrimSpace(scanner.Text())
+	}
+	close(p.lines)
+}
+
+func (p *Process) handshake() error {
+	if err := p.send(HelloCommand); err != nil {
+		return err
+	}
+	return p.waitFor(HandshakeTimeout, func(line string) bool {
+		if strings.HasPrefix(line, IDNamePrefix) {
+			p.name = strings.TrimPrefix(line, IDNamePrefix)
+		}
+		return line == HelloReply
+	})
+}
+
+func (p *Process) Name() string {
+	return p.name
+}
+
+// BestAction send the position and wait for the best move during the move time and a margin.
+// The best moves of the previous searches which timed out come first and are skipped.
+func (p *Process) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	if err := p.send(EncodePosition(g)); err != nil {
+		return game.Action{}, err
+	}
+	if err := p.send(fmt.Sprintf("%v %d", GoCommand, moveTime/time.Millisecond)); err != nil {
+		return game.Action{}, err
+	}
+	var notation string
+	err := p.waitFor(moveTime+MoveMargin, func(line string) bool {
+		if !strings.HasPrefix(line, BestMovePrefix) {
+			return false
+		}
+		if p.late > 0 {
+			p.late--
+			return false
+		}
+		notation = strings.TrimSpace(strings.TrimPrefix(line, BestMovePrefix))
+		return true
+	})
+	if err == ErrTimeout {
+		p.late++
+	}
+	if err != nil {
+		return game.Action{}, err
+	}
+	if notation == NoMove {
+		return game.Action{}, ErrNoMove
+	}
+	return game.ParseAction(notation)
+}
+
+// Close ask the engine to quit, killing it if it does not
+func (p *Process) Close() error {
+	p.send(QuitCommand)
+	p.stdin.Close()
+	go func() {
+		for range p.lines {
+		}
+	}()
+	done := make(chan error, 1)
+	go func() {
+		done <- p.cmd.Wait()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(QuitTimeout):
+		p.kill()
+		return <-done
+	}
+}
+
+func (p *Process) kill() {
+	if p.cmd.Process != nil {
+		p.cmd.Process.Kill()
+	}
+}
+
+func (p *Process) send(line string) error {
+	if _, err := io.WriteString(p.stdin, line+"\n"); err != nil {
+		return ErrCrashed
+	}
+	return nil
+}
+
+// waitFor read the lines of the engine until one is accepted, ignoring the others
+func (p *Process) waitFor(timeout time.Duration, accept func(string) bool) error {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case line, open := <-p.lines:
+			if !open {
+				return ErrCrashed
+			}
+			if accept(line) {
+				return nil
+			}
+		case <-deadline:
+			return ErrTimeout
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"quoridor/game"
+)
+
+// Commands and replies of the line-based engine protocol
+const (
+	HelloCommand    = "quoridor"
+	HelloReply      = "quoridorok"
+	IDNamePrefix    = "id name "
+	PositionCommand = "position"
+	GoCommand       = "go movetime"
+	BestMovePrefix  = "bestmove "
+	QuitCommand     = "quit"
+	// NoMove is the best move of a search which failed
+	NoMove = "none"
+)
+
+var goalNames = map[game.Direction]string{
+	game.NORTH: "north",
+	game.EAST:  "east",
+	game.SOUTH: "south",
+	game.WEST:  "west",
+}
+
+var jumpRuleNames = map[game.JumpRule]string{
+	game.OFFICIAL_JUMP:     "official",
+	game.EDGE_AS_WALL_JUMP: "edge-as-wall",
+	game.NO_DIAGONAL_JUMP:  "no-diagonal",
+}
+
+// EncodePosition get the position command describing the whole game, for instance
+// position size 9 variant standard jump official turn 1 actions 0 pawn a5 east 10 pawn i5 west 10 fence e3h
+func EncodePosition(g game.Game) string {
+	variant := g.Variant
+	if variant == "" {
+		variant = game.STANDARD
+	}
+	words := []string{PositionCommand,
+		"size", fmt.Sprint(g.Board.BoardSize),
+		"variant", variant,
+		"jump", jumpRuleNames[g.JumpRule],
+		"turn", fmt.Sprint(g.PawnTurn),
+		"actions", fmt.Sprint(g.TurnActions),
+	}
+	for _, pawn := range g.Pawns {
+		words = append(words, "pawn", pawn.Position.Notation(), goalNames[pawn.Goal], fmt.Sprint(pawn.FencesLeft))
+	}
+	for _, square := range g.Board.BlockedSquares {
+		words = append(words, "blocked", square.Notation())
+	}
+	for _, fence := range g.Fences {
+		words = append(words, "fence", fence.Notation())
+	}
+	return strings.Join(words, " ")
+}
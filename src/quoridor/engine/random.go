@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"math/rand"
+	"time"
+
+	"quoridor/game"
+)
+
+// Random plays any legal action
+type Random struct {
+	source *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{rand.New(rand.NewSource(seed))}
+}
+
+func (*Random) Name() string {
+	return RANDOM_ENGINE
+}
+
+func (r *Random) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	actions := g.LegalActions()
+	if len(actions) == 0 {
+		return game.Action{}, ErrNoLegalAction
+	}
+	return actions[r.source.Intn(len(actions))], nil
+}
+
+func (*Random) Close() error {
+	return nil
+}
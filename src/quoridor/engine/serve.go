@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"quoridor/game"
+)
+
+var goals = map[string]game.Direction{
+	"north": game.NORTH,
+	"east":  game.EAST,
+	"south": game.SOUTH,
+	"west":  game.WEST,
+}
+
+var jumpRules = map[string]game.JumpRule{
+	"official":     game.OFFICIAL_JUMP,
+	"edge-as-wall": game.EDGE_AS_WALL_JUMP,
+	"no-diagonal":  game.NO_DIAGONAL_JUMP,
+}
+
+// DecodePosition get the game described by the position command
+func DecodePosition(line string) (game.Game, error) {
+	words := strings.Fields(line)
+	if len(words) == 0 || words[0] != PositionCommand {
+		return game.Game{}, fmt.Errorf("not a position: %v", line)
+	}
+	conf := game.Configuration{}
+	pawns := []game.Pawn{}
+	fences := []game.Fence{}
+	turn, actions := 1, 0
+	var err error
+	for index := 1; index < len(words) && err == nil; index++ {
+		next := func() string {
+			index++
+			if index < len(words) {
+				return words[index]
+			}
+			err = fmt.Errorf("the position ends unexpectedly: %v", line)
+			return ""
+		}
+		switch words[index] {
+		case "size":
+			conf.BoardSize, err = strconv.Atoi(next())
+		case "variant":
+			conf.Variant = next()
+		case "jump":
+			conf.JumpRule = jumpRules[next()]
+		case "turn":
+			turn, err = strconv.Atoi(next())
+		case "actions":
+			actions, err = strconv.Atoi(next())
+		case "pawn":
+			var pawn game.Pawn
+			pawn.Position, err = game.ParsePosition(next())
+			pawn.Goal = goals[next()]
+			if err == nil {
+				pawn.FencesLeft, err = strconv.Atoi(next())
+			}
+			pawns = append(pawns, pawn)
+		case "blocked":
+			var square game.Position
+			square, err = game.ParsePosition(next())
+			conf.BlockedSquares = append(conf.BlockedSquares, square)
+		case "fence":
+			var action game.Action
+			action, err = game.ParseAction(next())
+			if err == nil && action.Fence != nil {
+				fences = append(fences, *action.Fence)
+			}
+		}
+	}
+	if err != nil {
+		return game.Game{}, err
+	}
+	if conf.Variant == game.STANDARD {
+		conf.Variant = ""
+	}
+	g, err := game.NewGameFromConfiguration(conf)
+	if err != nil {
+		return game.Game{}, err
+	}
+	if len(pawns) != len(g.Pawns) {
+		return game.Game{}, fmt.Errorf("the position needs %v pawns", len(g.Pawns))
+	}
+	g.Pawns, g.Fences, g.PawnTurn, g.TurnActions = pawns, fences, turn, actions
+	return g, nil
+}
+
+// Serve speak the protocol for the engine, as an external engine, until the quit command or the end of the input.
+// Each go is answered by a bestmove, none when there is no position to search or the search fails.
+func Serve(e Engine, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	var position game.Game
+	ready := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == HelloCommand:
+			fmt.Fprintf(out, "%v%v\n%v\n", IDNamePrefix, e.Name(), HelloReply)
+		case strings.HasPrefix(line, PositionCommand+" "):
+			g, err := DecodePosition(line)
+			if err != nil {
+				fmt.Fprintf(out, "info error %v\n", err)
+				ready = false
+				continue
+			}
+			position, ready = g, true
+		case strings.HasPrefix(line, GoCommand+" "):
+			milliseconds, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, GoCommand)))
+			if err != nil || !ready {
+				fmt.Fprintf(out, "info error no position to search\n%v%v\n", BestMovePrefix, NoMove)
+				continue
+			}
+			action, err := e.BestAction(position, time.Duration(milliseconds)*time.Millisecond)
+			if err != nil {
+				fmt.Fprintf(out, "info error %v\n%v%v\n", err, BestMovePrefix, NoMove)
+				continue
+			}
+			fmt.Fprintf(out, "%v%v\n", BestMovePrefix, action.Notation())
+		case line == QuitCommand:
+			return nil
+		}
+	}
+	return scanner.Err()
+}
@@ -6,8 +6,49 @@ const (
 	OUTSIDE_BOARD ErrorType = iota
 	NO_MOVE
 	OPPONENT
+	BLOCKED_SQUARE
+	NOT_FOUND
+	FORBIDDEN
+	NOT_READY
+	NOT_YOUR_TURN
+	CONFLICT
+	GAME_OVER
+	ILLEGAL_MOVE
+	ILLEGAL_FENCE
+	NO_MORE_FENCES
+	INVALID_ACTION
+	INVALID_CONFIGURATION
+	INVALID_REQUEST
+	UNAUTHORIZED
+	TOO_LARGE
 )
 
+var codes = map[ErrorType]string{
+	OUTSIDE_BOARD:         "outside_board",
+	NO_MOVE:               "no_move",
+	OPPONENT:              "opponent",
+	BLOCKED_SQUARE:        "blocked_square",
+	NOT_FOUND:             "not_found",
+	FORBIDDEN:             "forbidden",
+	NOT_READY:             "not_ready",
+	NOT_YOUR_TURN:         "not_your_turn",
+	CONFLICT:              "conflict",
+	GAME_OVER:             "game_over",
+	ILLEGAL_MOVE:          "illegal_move",
+	ILLEGAL_FENCE:         "illegal_fence",
+	NO_MORE_FENCES:        "no_more_fences",
+	INVALID_ACTION:        "invalid_action",
+	INVALID_CONFIGURATION: "invalid_configuration",
+	INVALID_REQUEST:       "invalid_request",
+	UNAUTHORIZED:          "unauthorized",
+	TOO_LARGE:             "too_large",
+}
+
+// Code get the stable machine-readable code of the error type
+func (t ErrorType) Code() string {
+	return codes[t]
+}
+
 func New(errorType ErrorType, message string) error {
 	return &GameError{errorType, message}
 }
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"fmt"
+
+	"quoridor/exception"
+)
+
+// ActionType discriminates the kind of action played during a turn
+type ActionType string
+
+const (
+	MOVE_ACTION  ActionType = "move"
+	FENCE_ACTION ActionType = "fence"
+)
+
+// Action is either a pawn move or a fence placement
+type Action struct {
+	Type     ActionType `json:"type"`
+	Position *Position  `json:"position,omitempty"`
+	Fence    *Fence     `json:"fence,omitempty"`
+}
+
+// PlayedAction is an action played by a pawn, the played actions of a game making its history
+type PlayedAction struct {
+	Number int       `json:"number"`
+	Action Action    `json:"action"`
+	From   *Position `json:"from,omitempty"`
+}
+
+// NewMoveAction create the action moving the current pawn to the destination
+func NewMoveAction(destination Position) Action {
+	return Action{MOVE_ACTION, &destination, nil}
+}
+
+// NewFenceAction create the action adding the fence on the board
+func NewFenceAction(fence Fence) Action {
+	return Action{FENCE_ACTION, nil, &fence}
+}
+
+// Validate check that the action carries the data required by its type
+func (a Action) Validate() error {
+	switch a.Type {
+	case MOVE_ACTION:
+		if a.Position == nil {
+			return exception.New(exception.INVALID_ACTION, "A move action requires a position")
+		}
+	case FENCE_ACTION:
+		if a.Fence == nil {
+			return exception.New(exception.INVALID_ACTION, "A fence action requires a fence")
+		}
+	default:
+		return exception.New(exception.INVALID_ACTION, fmt.Sprintf("The action type %v does not exist", a.Type))
+	}
+	return nil
+}
+
+// Apply play the action for the current pawn
+func (g Game) Apply(action Action) (Game, error) {
+	if err := action.Validate(); err != nil {
+		return Game{}, err
+	}
+	played, err := g.rules().Apply(g, action)
+	if err != nil {
+		return Game{}, err
+	}
+	record := PlayedAction{g.PawnTurn, action, nil}
+	if action.Type == MOVE_ACTION {
+		from := g.getCurrentPawn().Position
+		record.From = &from
+	}
+	played.History = append(g.History[:len(g.History):len(g.History)], record)
+	return played, nil
+}
+
+// LastAction get the latest action played in the game, if any
+func (g Game) LastAction() (PlayedAction, bool) {
+	if len(g.History) == 0 {
+		return PlayedAction{}, false
+	}
+	return g.History[len(g.History)-1], true
+}
+
+// LegalActions get all the actions the current pawn can play
+func (g Game) LegalActions() []Action {
+	actions := []Action{}
+	for _, position := range g.GetPossibleMoves() {
+		actions = append(actions, NewMoveAction(position))
+	}
+	for _, fence := range g.GetPossibleFences() {
+		actions = append(actions, NewFenceAction(fence))
+	}
+	return actions
+}
@@ -1,21 +1,25 @@
 package game
 
 import (
-	"errors"
+	"quoridor/exception"
 )
 
 //Board game board 
 type Board struct {
 	BoardSize int `json:"boardSize"`
 	Squares []Position `json:"squares"`
+	BlockedSquares Positions `json:"blockedSquares"`
 }
 
 func NewBoard(boardSize int) (*Board, error) {
 	if boardSize % 2 == 0 {
-		return nil, errors.New("The board size must be an odd number")
+		return nil, exception.New(exception.INVALID_CONFIGURATION, "The board size must be an odd number")
 	}
 	if boardSize < 3 {
-		return nil, errors.New("The board size must be at least 3")
+		return nil, exception.New(exception.INVALID_CONFIGURATION, "The board size must be at least 3")
+	}
+	if boardSize > MaxBoardSize {
+		return nil, exception.New(exception.INVALID_CONFIGURATION, "The board size must be at most 25")
 	}
 	squares := []Position{}
 	for row := 0; row < boardSize; row++ {
@@ -23,7 +27,7 @@ func NewBoard(boardSize int) (*Board, error) {
 			squares = append(squares, Position{column, row})
 		}
 	}
-	return &Board{boardSize, squares}, nil
+	return &Board{boardSize, squares, Positions{}}, nil
 }
 
 func (board Board) IsInBoard(position Position) bool { 
@@ -31,3 +35,33 @@ func (board Board) IsInBoard(position Position) bool {
     col := position.Column
     return row >= 0 && row < board.BoardSize && col >= 0 && col < board.BoardSize; 
 }
+
+//IsBlocked check whether the square has been marked unavailable
+func (board Board) IsBlocked(position Position) bool {
+	return board.BlockedSquares.IndexOf(position) != -1
+}
+
+//IsAvailable check whether a pawn can stand on the square
+func (board Board) IsAvailable(position Position) bool {
+	return board.IsInBoard(position) && !board.IsBlocked(position)
+}
+
+//BlockSquare mark the square as unavailable for the whole game
+func (board *Board) BlockSquare(position Position) error {
+	if !board.IsInBoard(position) {
+		return exception.New(exception.INVALID_CONFIGURATION, "The blocked square is not inside the board")
+	}
+	if board.IsBlocked(position) {
+		return exception.New(exception.INVALID_CONFIGURATION, "The square is already blocked")
+	}
+	board.BlockedSquares = append(board.BlockedSquares, position)
+	return nil
+}
+
+//IsFenceInBoard check whether the fence lies between the squares of the board
+func (board Board) IsFenceInBoard(fence Fence) bool {
+	numberOfIntersections := board.BoardSize - 1
+	row := fence.NWSquare.Row
+	col := fence.NWSquare.Column
+	return row >= 0 && row < numberOfIntersections && col >= 0 && col < numberOfIntersections
+}
@@ -1,7 +1,19 @@
 package game
 
+// Default configuration
+const (
+	DefaultBoardSize = 9
+	DefaultNumberOfFences = 10
+	// MaxBoardSize is the largest board, each column being noted by a single letter
+	MaxBoardSize = 25
+)
+
 //Configuration options to create a game
 type Configuration struct {
-	BoardSize int `json:boardSize`
-	NumberOfFencesPerPawnPlayer int `json:numberOfFencesPerPlayer`
+	BoardSize int `json:"boardSize"`
+	NumberOfFencesPerPawnPlayer int `json:"numberOfFencesPerPlayer"`
+	BlockedSquares []Position `json:"blockedSquares"`
+	Walls []Fence `json:"walls"`
+	JumpRule JumpRule `json:"jumpRule"`
+	Variant string `json:"variant"`
 }
@@ -2,8 +2,8 @@ package game
 
 // Fence represents a fence on the board
 type Fence struct {
-	NWSquare Position `json:square`
-	Horizontal bool `json:horizontal`
+	NWSquare Position `json:"square"`
+	Horizontal bool `json:"horizontal"`
 }
 
 func (f Fence) Equals(other Fence) bool {
@@ -1,7 +1,6 @@
 package game
 
 import (
-	"errors"
 	"fmt"
 
 	"quoridor/exception"
@@ -9,12 +8,6 @@ import (
 	"github.com/lithammer/shortuuid"
 )
 
-type Move struct {
-	to Position
-	jumpLeft Position
-	jumpRight Position
-}
-
 // Game is the controller
 type Game struct {
 	ID       string  `json:"id"`
@@ -23,45 +16,130 @@ type Game struct {
 	Pawns    []Pawn  `json:"pawn"`
 	Fences   []Fence `json:"fences"`
 	Board    *Board  `json:"board"`
+	JumpRule JumpRule `json:"jumpRule"`
+	Variant string `json:"variant"`
+	TurnActions int `json:"turnActions"`
+	Resigned int `json:"resigned,omitempty"`
+	History []PlayedAction `json:"history"`
 }
 
-// NewGame create a new game depending on the configuration
+// NewGame create a new game on an empty board with the default number of fences
 func NewGame(boardSize int) (Game, error) {
+	return NewGameFromConfiguration(Configuration{BoardSize: boardSize, NumberOfFencesPerPawnPlayer: DefaultNumberOfFences})
+}
+
+// NewGameFromConfiguration create a new game depending on the configuration
+func NewGameFromConfiguration(conf Configuration) (Game, error) {
+	ruleset, err := GetRuleset(conf.Variant)
+	if err != nil {
+		return Game{}, err
+	}
+	conf, err = ruleset.Configure(conf)
+	if err != nil {
+		return Game{}, err
+	}
+	boardSize := conf.BoardSize
 	board, err := NewBoard(boardSize)
 	if err != nil {
 		return Game{}, err
 	}
 	lineCenter := (boardSize - 1) / 2
 	pawns := []Pawn{
-		Pawn{Position{0, lineCenter}, EAST},
-		Pawn{Position{boardSize - 1, lineCenter}, WEST},
+		Pawn{Position{0, lineCenter}, EAST, conf.NumberOfFencesPerPawnPlayer},
+		Pawn{Position{boardSize - 1, lineCenter}, WEST, conf.NumberOfFencesPerPawnPlayer},
 	}
 	id := shortuuid.New()
-	return Game{id, false, 1, pawns, []Fence{}, board}, nil
+	g := Game{id, false, 1, pawns, []Fence{}, board, conf.JumpRule, conf.Variant, 0, 0, []PlayedAction{}}
+	for _, square := range conf.BlockedSquares {
+		if !isPositionFree(square, g.Pawns) {
+			return Game{}, exception.New(exception.INVALID_CONFIGURATION, "A pawn cannot start on a blocked square")
+		}
+		if err := g.Board.BlockSquare(square); err != nil {
+			return Game{}, err
+		}
+	}
+	for _, wall := range conf.Walls {
+		g, err = g.addWall(wall)
+		if err != nil {
+			return Game{}, err
+		}
+	}
+	if !g.hasAccessToGoalLines(g.Fences) {
+		return Game{}, exception.New(exception.INVALID_CONFIGURATION, "No more access to goal line")
+	}
+	return g, nil
+}
+
+func (g Game) addWall(wall Fence) (Game, error) {
+	if !g.Board.IsFenceInBoard(wall) {
+		return Game{}, exception.New(exception.INVALID_CONFIGURATION, "The wall is not inside the board")
+	}
+	positionSquare := NewPositionSquare(wall.NWSquare)
+	if g.hasAlreadyAFenceAtTheSamePosition(wall.NWSquare) || g.hasNeighbourFence(wall.Horizontal, positionSquare) {
+		return Game{}, exception.New(exception.INVALID_CONFIGURATION, "The wall overlaps another one")
+	}
+	g.Fences = append(g.Fences, wall)
+	return g, nil
+}
+
+func (g Game) rules() Ruleset {
+	ruleset, err := GetRuleset(g.Variant)
+	if err != nil {
+		return Standard{}
+	}
+	return ruleset
 }
 
 // AddFence add the fence on the board
 func (g Game) AddFence(fence Fence) (Game, error) {
+	return g.Apply(NewFenceAction(fence))
+}
+
+// GetPossibleFences get all the places where the current pawn can add a fence
+func (g Game) GetPossibleFences() Fences {
+	return g.rules().LegalFences(g)
+}
+
+func (g Game) addFence(fence Fence) (Game, error) {
 	if g.Over {
-		return Game{}, errors.New("Game is over, unable to add a fence")
+		return Game{}, exception.New(exception.GAME_OVER, "Game is over, unable to add a fence")
+	}
+	if g.getCurrentPawn().FencesLeft == 0 {
+		return Game{}, exception.New(exception.NO_MORE_FENCES, "No more fences to add")
+	}
+	if !g.Board.IsFenceInBoard(fence) {
+		return Game{}, exception.New(exception.ILLEGAL_FENCE, "The fence is not inside the board")
 	}
 	positionSquare := NewPositionSquare(fence.NWSquare)
 	if g.hasAlreadyAFenceAtTheSamePosition(fence.NWSquare) || g.hasNeighbourFence(fence.Horizontal, positionSquare) {
-		return Game{}, errors.New("The fence overlaps another one")
+		return Game{}, exception.New(exception.ILLEGAL_FENCE, "The fence overlaps another one")
 	}
-	g, err := g.addFenceIfCrossable(fence)
-	if err != nil {
-		return Game{}, err
+	return g.addFenceIfCrossable(fence)
+}
+
+func (g Game) getPossibleFences() Fences {
+	numberOfIntersections := g.Board.BoardSize - 1
+	possibilities := Fences{}
+	for row := 0; row < numberOfIntersections; row++ {
+		for column := 0; column < numberOfIntersections; column++ {
+			for _, horizontal := range []bool{true, false} {
+				fence := Fence{Position{column, row}, horizontal}
+				if _, err := g.addFence(fence); err == nil {
+					possibilities = append(possibilities, fence)
+				}
+			}
+		}
 	}
-	g.PawnTurn = g.getNextPawnTurn()
-	return g, nil
+	return possibilities
 }
 
 func (g Game) addFenceIfCrossable(fence Fence) (Game, error) {
 	if !g.IsCrossable(fence) {
-		return Game{}, errors.New("No more access to goal line")
+		return Game{}, exception.New(exception.ILLEGAL_FENCE, "No more access to goal line")
 	}
-	g.Fences = append(g.Fences, fence)
+	g.Fences = append(append([]Fence{}, g.Fences...), fence)
+	g.Pawns = g.copyPawns()
+	g.Pawns[g.PawnTurn-1].FencesLeft--
 	return g, nil
 }
 
@@ -98,7 +176,11 @@ func (g Game) hasNeighbourFence(isHorizontal bool, ps PositionSquare) bool {
 
 // IsCrossable check whether the fence can be added and let a path for all pawns to their goal line
 func (g Game) IsCrossable(fence Fence) bool {
-	fences := append(g.Fences, fence)
+	fences := append(append([]Fence{}, g.Fences...), fence)
+	return g.hasAccessToGoalLines(fences)
+}
+
+func (g Game) hasAccessToGoalLines(fences []Fence) bool {
 	for i := range g.Pawns {
 		pawn := g.Pawns[i]
 		destinations := g.getGoalLine(pawn)
@@ -109,8 +191,8 @@ func (g Game) IsCrossable(fence Fence) bool {
 	return true
 }
 
-func (g Game) getGoalLine(pawn Pawn) []Position {
-	destinations := []Position{}
+func (g Game) getGoalLine(pawn Pawn) Positions {
+	destinations := Positions{}
 	var column int
 	if pawn.Goal == EAST {
 		column = g.Board.BoardSize - 1
@@ -123,46 +205,72 @@ func (g Game) getGoalLine(pawn Pawn) []Position {
 	return destinations
 }
 
-// MovePawn move the pawn to the destination
-func (g Game) MovePawn(destination Position) (Game, error) {
+// DistanceToGoal get the length of the shortest path of the pawn to its goal line, ignoring the other pawns
+func (g Game) DistanceToGoal(number int) int {
+	pawn := g.Pawns[number-1]
+	return Path(*g.Board, g.Fences, pawn.Position, g.getGoalLine(pawn))
+}
+
+// Resign end the game, the opponent of the pawn winning
+func (g Game) Resign(number int) (Game, error) {
 	if g.Over {
-		return Game{}, errors.New("Game is over, unable to move the pawn")
+		return Game{}, exception.New(exception.GAME_OVER, "Game is over")
 	}
-	if !g.Board.IsInBoard(destination) {
-		return Game{}, errors.New("The new position is not inside the board")
+	if number < 1 || number > len(g.Pawns) {
+		return Game{}, exception.New(exception.INVALID_ACTION, fmt.Sprintf("The pawn %v does not exist", number))
 	}
-	moves := g.GetPossibleMoves()
-	if moves.IndexOf(destination) == -1 {
-		return Game{}, fmt.Errorf("It is not possible to move to %v", destination)
+	g.Over = true
+	g.Resigned = number
+	return g, nil
+}
+
+// Winner get the number of the pawn which has reached its goal line or whose opponent has resigned, 0 while the game is not over
+func (g Game) Winner() int {
+	if g.Resigned != 0 {
+		return len(g.Pawns) + 1 - g.Resigned
 	}
-	g = g.setCurrentPawnPosition(destination)
-	over, err := g.isOver()
-	if err != nil {
-		return Game{}, err
+	for index, pawn := range g.Pawns {
+		if g.hasReachedGoalLine(pawn) {
+			return index + 1
+		}
 	}
-	g.Over = over
-	g.PawnTurn = g.getNextPawnTurn()
-	return g, nil
+	return 0
 }
 
-func (g Game) GetPossibleMoves() Positions {
-	positions := Positions{}
+func (g Game) hasReachedGoalLine(pawn Pawn) bool {
+	return g.getGoalLine(pawn).IndexOf(pawn.Position) != -1
+}
 
-	northMove := Move{Position{0, -1}, Position{-1, 0}, Position{1, 0}}
-	northPositions := g.getDirectionPossibleMoves(northMove)
-	positions = positions.appendIfNotPresent(northPositions)
+// MovePawn move the pawn to the destination
+func (g Game) MovePawn(destination Position) (Game, error) {
+	return g.Apply(NewMoveAction(destination))
+}
 
-	eastMove := Move{Position{1, 0}, Position{0, -1}, Position{0, 1}}
-	eastPositions := g.getDirectionPossibleMoves(eastMove)
-	positions = positions.appendIfNotPresent(eastPositions)
+// GetPossibleMoves get all the squares where the current pawn can move
+func (g Game) GetPossibleMoves() Positions {
+	return g.rules().LegalMoves(g)
+}
 
-	southMove := Move{Position{0, 1}, Position{-1, 0}, Position{1, 0}}
-	southPositions := g.getDirectionPossibleMoves(southMove)
-	positions = positions.appendIfNotPresent(southPositions)
+func (g Game) movePawn(destination Position) (Game, error) {
+	if g.Over {
+		return Game{}, exception.New(exception.GAME_OVER, "Game is over, unable to move the pawn")
+	}
+	if !g.Board.IsInBoard(destination) {
+		return Game{}, exception.New(exception.ILLEGAL_MOVE, "The new position is not inside the board")
+	}
+	moves := g.getPossibleMoves()
+	if moves.IndexOf(destination) == -1 {
+		return Game{}, exception.New(exception.ILLEGAL_MOVE, fmt.Sprintf("It is not possible to move to %v", destination))
+	}
+	return g.setCurrentPawnPosition(destination), nil
+}
 
-	westMove := Move{Position{-1, 0}, Position{0, -1}, Position{0, 1}}
-	westPositions := g.getDirectionPossibleMoves(westMove)
-	positions = positions.appendIfNotPresent(westPositions)
+func (g Game) getPossibleMoves() Positions {
+	positions := Positions{}
+	for _, move := range []Move{northMove, eastMove, southMove, westMove} {
+		directionPositions := g.getDirectionPossibleMoves(move)
+		positions = positions.appendIfNotPresent(directionPositions)
+	}
 	return positions
 }
 
@@ -178,21 +286,8 @@ func (g Game) getDirectionPossibleMoves(move Move) Positions {
 		positions = append(positions, toPosition)
 		return positions
 	}
-	toPosition = from.Copy(move.to.Column, move.to.Row)
-	jumpPosition, errJump := g.getPossiblePosition(toPosition, move.to.Column, move.to.Row)
-	if errJump == nil {
-		positions = append(positions, jumpPosition)
-		return positions
-	}
-	jumpLeftPosition, errLeftJump := g.getPossiblePosition(toPosition, move.jumpLeft.Column, move.jumpLeft.Row)
-	if errLeftJump == nil {
-		positions = append(positions, jumpLeftPosition)
-	}
-	jumpRightPosition, errRightJump := g.getPossiblePosition(toPosition, move.jumpRight.Column, move.jumpRight.Row)
-	if errRightJump == nil {
-		positions = append(positions, jumpRightPosition)
-	}
-	return positions
+	opponent := from.Copy(move.to.Column, move.to.Row)
+	return g.JumpRule.jumps(g, opponent, move)
 }
 
 func (g Game) getPossiblePosition(from Position, col, row int) (Position, error) {
@@ -200,6 +295,9 @@ func (g Game) getPossiblePosition(from Position, col, row int) (Position, error)
 	if !g.Board.IsInBoard(to) {
 		return Position{}, exception.New(exception.OUTSIDE_BOARD, "Outside")
 	}
+	if g.Board.IsBlocked(to) {
+		return Position{}, exception.New(exception.BLOCKED_SQUARE, "Blocked")
+	}
 	if !CanCross(from, to, g.Fences) {
 		return Position{}, exception.New(exception.NO_MOVE, "NotCrossable")
 	}
@@ -238,17 +336,6 @@ func CanCross(from Position, to Position, fences Fences) bool {
 	return !fences.Contains(fence1, fence2)
 }
 
-func (g Game) isOver() (bool, error) {
-	pawn := g.getCurrentPawn()
-	if pawn.Goal == EAST {
-		return pawn.Position.Column == g.Board.BoardSize-1, nil
-	}
-	if pawn.Goal == WEST {
-		return pawn.Position.Column == 0, nil
-	}
-	return false, fmt.Errorf("Goal direction not supported %v", pawn.Goal)
-}
-
 func (g Game) getNextPawnTurn() int {
 	if g.PawnTurn+1 > len(g.Pawns) {
 		return 1
@@ -261,79 +348,16 @@ func (g Game) getCurrentPawn() Pawn {
 }
 
 func (g Game) setCurrentPawnPosition(newPosition Position) Game {
+	g.Pawns = g.copyPawns()
 	g.Pawns[g.PawnTurn-1].Position = newPosition
 	return g
 }
 
-type BoardItem int
-
-const (
-	SQUARE BoardItem = 0
-	NO_FENCE BoardItem = 1
-	FENCE BoardItem = 2
-	PAWN_1 BoardItem = 3
-	PAWN_2 BoardItem = 4
-)
-
-func (g Game) GetTextBoard() string {
-	board:= g.buildBoard()
-
-	lines := ""
-	for i := 0; i < len(board); i++ {
-		line := " "
-		for j := 0; j < len(board); j++ {
-			if board[i][j] == SQUARE {
-				line += "\u25a1 "
-			} else if board[i][j] == NO_FENCE {
-				line += "  "
-			} else if board[i][j] == FENCE {
-				line += "\u25fc "
-			} else if board[i][j] == PAWN_1 {
-				line += "\u25b2 "
-			} else if board[i][j] == PAWN_2 {
-				line += "\u25b3 "
-			}
-		}
-		lines += line + "\n"
-	}
-	return lines
-}
-
-func isOdd(number int) bool {
-	return number % 2 != 0
+func (g Game) copyPawns() []Pawn {
+	return append([]Pawn{}, g.Pawns...)
 }
 
-func (g Game) buildBoard() [][]BoardItem {
-	boardSize := g.Board.BoardSize * 2 - 1
-	board := make([][]BoardItem, boardSize)
-	for i := 0; i < boardSize; i++ {
-		board[i] = make([]BoardItem, boardSize)
-	}
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if isOdd(i) || isOdd(j) {
-				board[i][j] = NO_FENCE
-			}
-		}
-	}
-	for _, fence := range g.Fences {
-		position := fence.NWSquare
-		row := position.Row * 2
-		col := position.Column * 2
-		if fence.Horizontal {
-			board[row + 1][col] = FENCE
-			board[row + 1][col + 1] = FENCE
-			board[row + 1][col + 2] = FENCE
-		} else {
-			board[row][col + 1] = FENCE
-			board[row + 1][col + 1] = FENCE
-			board[row + 2][col + 1] = FENCE
-		}
-	}
-	pawnNumber := PAWN_1
-	for _, pawn := range g.Pawns {
-		board[pawn.Position.Row * 2][pawn.Position.Column * 2] = pawnNumber
-		pawnNumber++ 
-	}
-	return board
+// GetTextBoard get the board drawn with Unicode glyphs
+func (g Game) GetTextBoard() string {
+	return g.Render(RenderOptions{})
 }
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"quoridor/exception"
+)
+
+// JumpRule defines how a pawn may jump over an adjacent opponent
+type JumpRule int
+
+const (
+	// OFFICIAL_JUMP allows a diagonal jump only when a fence, a blocked square or another pawn stands behind the opponent
+	OFFICIAL_JUMP JumpRule = iota
+	// EDGE_AS_WALL_JUMP also allows a diagonal jump when the opponent stands on the edge of the board
+	EDGE_AS_WALL_JUMP
+	// NO_DIAGONAL_JUMP only allows the straight jump
+	NO_DIAGONAL_JUMP
+)
+
+// Move describes a direction and the two sides used by diagonal jumps
+type Move struct {
+	to        Position
+	jumpLeft  Position
+	jumpRight Position
+}
+
+var (
+	northMove = Move{Position{0, -1}, Position{-1, 0}, Position{1, 0}}
+	eastMove  = Move{Position{1, 0}, Position{0, -1}, Position{0, 1}}
+	southMove = Move{Position{0, 1}, Position{-1, 0}, Position{1, 0}}
+	westMove  = Move{Position{-1, 0}, Position{0, -1}, Position{0, 1}}
+)
+
+// jumps get the positions reachable by jumping over the opponent standing next to the pawn
+func (rule JumpRule) jumps(g Game, opponent Position, move Move) Positions {
+	positions := Positions{}
+	jumpPosition, err := g.getPossiblePosition(opponent, move.to.Column, move.to.Row)
+	if err == nil {
+		positions = append(positions, jumpPosition)
+		return positions
+	}
+	if !rule.allowsDiagonalJump(err) {
+		return positions
+	}
+	jumpLeftPosition, errLeftJump := g.getPossiblePosition(opponent, move.jumpLeft.Column, move.jumpLeft.Row)
+	if errLeftJump == nil {
+		positions = append(positions, jumpLeftPosition)
+	}
+	jumpRightPosition, errRightJump := g.getPossiblePosition(opponent, move.jumpRight.Column, move.jumpRight.Row)
+	if errRightJump == nil {
+		positions = append(positions, jumpRightPosition)
+	}
+	return positions
+}
+
+// allowsDiagonalJump check whether the reason the straight jump failed lets the pawn jump on the sides
+func (rule JumpRule) allowsDiagonalJump(straightJumpError error) bool {
+	if rule == NO_DIAGONAL_JUMP {
+		return false
+	}
+	if exception.MatchGameError(straightJumpError, exception.OUTSIDE_BOARD) {
+		return rule == EDGE_AS_WALL_JUMP
+	}
+	return exception.MatchGameError(straightJumpError, exception.NO_MOVE) ||
+		exception.MatchGameError(straightJumpError, exception.BLOCKED_SQUARE) ||
+		exception.MatchGameError(straightJumpError, exception.OPPONENT)
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+
+	"quoridor/exception"
+)
+
+// Notation suffixes of the fences, a move having no suffix
+const (
+	HorizontalSuffix = "h"
+	VerticalSuffix   = "v"
+)
+
+// Notation get the square in the algebraic notation, the column as a letter from a and the row as a number from 1
+func (p Position) Notation() string {
+	return fmt.Sprintf("%c%d", 'a'+p.Column, p.Row+1)
+}
+
+// ParsePosition get the square of the algebraic notation
+func ParsePosition(notation string) (Position, error) {
+	if len(notation) < 2 || notation[0] < 'a' || notation[0] > 'z' {
+		return Position{}, invalidNotation(notation)
+	}
+	row, err := strconv.Atoi(notation[1:])
+	if err != nil || row < 1 {
+		return Position{}, invalidNotation(notation)
+	}
+	return Position{int(notation[0] - 'a'), row - 1}, nil
+}
+
+// Notation get the fence as its north west square followed by h or v
+func (f Fence) Notation() string {
+	if f.Horizontal {
+		return f.NWSquare.Notation() + HorizontalSuffix
+	}
+	return f.NWSquare.Notation() + VerticalSuffix
+}
+
+// Notation get the action as e3 for a move to e3 or as e3h for a horizontal fence from e3
+func (a Action) Notation() string {
+	if a.Type == FENCE_ACTION && a.Fence != nil {
+		return a.Fence.Notation()
+	}
+	if a.Position != nil {
+		return a.Position.Notation()
+	}
+	return ""
+}
+
+// ParseAction get the action of the notation
+func ParseAction(notation string) (Action, error) {
+	if len(notation) == 0 {
+		return Action{}, invalidNotation(notation)
+	}
+	suffix := notation[len(notation)-1:]
+	if suffix != HorizontalSuffix && suffix != VerticalSuffix {
+		position, err := ParsePosition(notation)
+		if err != nil {
+			return Action{}, err
+		}
+		return NewMoveAction(position), nil
+	}
+	square, err := ParsePosition(notation[:len(notation)-1])
+	if err != nil {
+		return Action{}, invalidNotation(notation)
+	}
+	return NewFenceAction(Fence{square, suffix == HorizontalSuffix}), nil
+}
+
+func invalidNotation(notation string) error {
+	return exception.New(exception.INVALID_ACTION, fmt.Sprintf("The notation %v is not valid", notation))
+}
@@ -4,6 +4,7 @@ package game
 type Pawn struct {
 	Position Position `json:"position"`
 	Goal Direction `json:"goal"`
+	FencesLeft int `json:"fencesLeft"`
 }
 
 type Pawns []Pawn
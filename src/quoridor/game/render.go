@@ -0,0 +1,168 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderOptions select what the text board shows besides the squares, the fences and the pawns
+type RenderOptions struct {
+	Coordinates bool
+	LegalMoves  bool
+	LastMove    bool
+	FencesLeft  bool
+	ASCII       bool
+	Color       bool
+	// Selection is the square or the fence of the action shown reversed in color mode, as a cursor
+	Selection *Action
+}
+
+type glyphs struct {
+	square  string
+	fence   string
+	blocked string
+	legal   string
+	pawns   []string
+}
+
+var unicodeGlyphs = glyphs{"□", "◼", "▨", "◦", []string{"▲", "△", "◆", "◇"}}
+var asciiGlyphs = glyphs{".", "#", "X", "*", []string{"1", "2", "3", "4"}}
+
+// ANSI styles of the color mode
+const (
+	resetStyle    = "0"
+	boldStyle     = "1"
+	dimStyle      = "2"
+	reverseStyle  = "7"
+	fenceStyle    = "33"
+	legalStyle    = "32"
+	currentStyle  = "1;4"
+	lastMoveStyle = "7"
+)
+
+var pawnStyles = []string{"31", "34", "32", "35"}
+
+type cell struct {
+	symbol string
+	styles []string
+}
+
+// Render draw the board as text, one line per row of squares and one per row of gaps between them
+func (g Game) Render(options RenderOptions) string {
+	symbols := unicodeGlyphs
+	if options.ASCII {
+		symbols = asciiGlyphs
+	}
+	cells := g.renderCells(symbols, options)
+	var builder strings.Builder
+	if options.Coordinates {
+		builder.WriteString("  ")
+		for column := 0; column < g.Board.BoardSize; column++ {
+			fmt.Fprintf(&builder, " %c  ", 'a'+column)
+		}
+		builder.WriteString("\n")
+	}
+	for row, line := range cells {
+		if options.Coordinates && row%2 == 0 {
+			fmt.Fprintf(&builder, "%2d", row/2+1)
+		} else if options.Coordinates {
+			builder.WriteString("  ")
+		}
+		builder.WriteString(" ")
+		for _, c := range line {
+			builder.WriteString(c.draw(options.Color) + " ")
+		}
+		builder.WriteString("\n")
+	}
+	if options.FencesLeft {
+		for index, pawn := range g.Pawns {
+			turn := ""
+			if index+1 == g.PawnTurn && !g.Over {
+				turn = " to play"
+			}
+			symbol := cell{symbols.pawns[index%len(symbols.pawns)], []string{pawnStyles[index%len(pawnStyles)]}}
+			fmt.Fprintf(&builder, "%v %v: %v fences left%v\n", symbol.draw(options.Color), index+1, pawn.FencesLeft, turn)
+		}
+	}
+	if move, found := g.LastAction(); options.LastMove && found {
+		fmt.Fprintf(&builder, "Last move: %v %v\n", move.Number, move.Action.Notation())
+	}
+	if g.Over {
+		fmt.Fprintf(&builder, "Pawn %v wins\n", g.Winner())
+	}
+	return builder.String()
+}
+
+func (g Game) renderCells(symbols glyphs, options RenderOptions) [][]cell {
+	length := g.Board.BoardSize*2 - 1
+	cells := make([][]cell, length)
+	for row := range cells {
+		cells[row] = make([]cell, length)
+		for column := range cells[row] {
+			cells[row][column] = cell{" ", nil}
+			if row%2 == 0 && column%2 == 0 {
+				cells[row][column] = cell{symbols.square, nil}
+			}
+		}
+	}
+	if options.LegalMoves && !g.Over {
+		for _, move := range g.GetPossibleMoves() {
+			cells[move.Row*2][move.Column*2] = cell{symbols.legal, []string{legalStyle}}
+		}
+	}
+	for _, square := range g.Board.BlockedSquares {
+		cells[square.Row*2][square.Column*2] = cell{symbols.blocked, []string{dimStyle}}
+	}
+	for _, fence := range g.Fences {
+		for _, position := range fence.cells() {
+			cells[position.Row][position.Column] = cell{symbols.fence, []string{fenceStyle}}
+		}
+	}
+	for index, pawn := range g.Pawns {
+		styles := []string{pawnStyles[index%len(pawnStyles)]}
+		if index+1 == g.PawnTurn && !g.Over {
+			styles = append(styles, currentStyle)
+		}
+		cells[pawn.Position.Row*2][pawn.Position.Column*2] = cell{symbols.pawns[index%len(symbols.pawns)], styles}
+	}
+	if move, found := g.LastAction(); options.LastMove && found {
+		for _, position := range move.Action.cells() {
+			c := &cells[position.Row][position.Column]
+			c.styles = append(c.styles[:len(c.styles):len(c.styles)], lastMoveStyle)
+		}
+	}
+	if options.Selection != nil && !g.Over {
+		for _, position := range options.Selection.cells() {
+			c := &cells[position.Row][position.Column]
+			if c.symbol == " " {
+				c.symbol = symbols.fence
+			}
+			c.styles = append(c.styles[:len(c.styles):len(c.styles)], reverseStyle)
+		}
+	}
+	return cells
+}
+
+// cells get the positions of the square or of the fence of the action in the grid of the squares and of the gaps
+func (a Action) cells() []Position {
+	if a.Type == MOVE_ACTION {
+		return []Position{{a.Position.Column * 2, a.Position.Row * 2}}
+	}
+	return a.Fence.cells()
+}
+
+// cells get the positions of the fence in the grid of the squares and of the gaps between them
+func (f Fence) cells() []Position {
+	row, column := f.NWSquare.Row*2, f.NWSquare.Column*2
+	if f.Horizontal {
+		return []Position{{column, row + 1}, {column + 1, row + 1}, {column + 2, row + 1}}
+	}
+	return []Position{{column + 1, row}, {column + 1, row + 1}, {column + 1, row + 2}}
+}
+
+func (c cell) draw(color bool) string {
+	if !color || len(c.styles) == 0 {
+		return c.symbol
+	}
+	return "\x1b[" + strings.Join(c.styles, ";") + "m" + c.symbol + "\x1b[" + resetStyle + "m"
+}
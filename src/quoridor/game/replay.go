@@ -0,0 +1,31 @@
+package game
+
+import (
+	"fmt"
+
+	"quoridor/exception"
+)
+
+// Replay get the positions of a game from its start with the configuration, then after each action of its history
+func Replay(conf Configuration, id string, history []PlayedAction) ([]Game, error) {
+	g, err := NewGameFromConfiguration(conf)
+	if err != nil {
+		return nil, err
+	}
+	g.ID = id
+	return ReplayFrom(g, history)
+}
+
+// ReplayFrom get the positions of a game from the starting position, then after each action of its history
+func ReplayFrom(g Game, history []PlayedAction) ([]Game, error) {
+	var err error
+	positions := []Game{g}
+	for ply, played := range history {
+		g, err = g.Apply(played.Action)
+		if err != nil {
+			return nil, exception.New(exception.INVALID_ACTION, fmt.Sprintf("The action %v of the ply %v cannot be replayed: %v", played.Action.Notation(), ply+1, err.Error()))
+		}
+		positions = append(positions, g)
+	}
+	return positions, nil
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"fmt"
+
+	"quoridor/exception"
+)
+
+// Ruleset defines the rules of a Quoridor variant
+type Ruleset interface {
+	// Configure check the configuration and apply the defaults of the variant
+	Configure(conf Configuration) (Configuration, error)
+	// LegalMoves get the squares where the current pawn can move
+	LegalMoves(g Game) Positions
+	// LegalFences get the places where the current pawn can add a fence
+	LegalFences(g Game) Fences
+	// Apply play the action for the current pawn and hand over the turn if needed
+	Apply(g Game, action Action) (Game, error)
+	// IsOver check whether the game has reached its end
+	IsOver(g Game) bool
+}
+
+// Available variants
+const (
+	STANDARD            = "standard"
+	KIDS                = "kids"
+	MOVE_OR_FENCE_TWICE = "move-or-fence-twice"
+)
+
+// Quoridor Kids defaults
+const (
+	KidsBoardSize      = 7
+	KidsNumberOfFences = 5
+)
+
+// ActionsPerTwiceTurn is the number of actions a pawn plays per turn in the move-or-fence-twice variant
+const ActionsPerTwiceTurn = 2
+
+var rulesets = map[string]Ruleset{
+	STANDARD:            Standard{},
+	KIDS:                Kids{},
+	MOVE_OR_FENCE_TWICE: MoveOrFenceTwice{},
+}
+
+// GetRuleset get the ruleset of the variant, the standard one by default
+func GetRuleset(variant string) (Ruleset, error) {
+	if variant == "" {
+		return Standard{}, nil
+	}
+	ruleset, found := rulesets[variant]
+	if !found {
+		return nil, exception.New(exception.INVALID_CONFIGURATION, fmt.Sprintf("The variant %v does not exist", variant))
+	}
+	return ruleset, nil
+}
+
+// Standard is the official two players ruleset
+type Standard struct{}
+
+func (Standard) Configure(conf Configuration) (Configuration, error) {
+	return conf, nil
+}
+
+func (Standard) LegalMoves(g Game) Positions {
+	return g.getPossibleMoves()
+}
+
+func (Standard) LegalFences(g Game) Fences {
+	return g.getPossibleFences()
+}
+
+func (r Standard) Apply(g Game, action Action) (Game, error) {
+	g, err := r.play(g, action)
+	if err != nil {
+		return Game{}, err
+	}
+	g.PawnTurn = g.getNextPawnTurn()
+	return g, nil
+}
+
+// play the action without handing over the turn
+func (r Standard) play(g Game, action Action) (Game, error) {
+	if action.Type == FENCE_ACTION {
+		return g.addFence(*action.Fence)
+	}
+	g, err := g.movePawn(*action.Position)
+	if err != nil {
+		return Game{}, err
+	}
+	g.Over = r.IsOver(g)
+	return g, nil
+}
+
+func (Standard) IsOver(g Game) bool {
+	for _, pawn := range g.Pawns {
+		if g.hasReachedGoalLine(pawn) {
+			return true
+		}
+	}
+	return false
+}
+
+// Kids is played on a smaller board with fewer fences
+type Kids struct {
+	Standard
+}
+
+func (Kids) Configure(conf Configuration) (Configuration, error) {
+	if conf.BoardSize == 0 {
+		conf.BoardSize = KidsBoardSize
+	}
+	if conf.NumberOfFencesPerPawnPlayer == 0 {
+		conf.NumberOfFencesPerPawnPlayer = KidsNumberOfFences
+	}
+	if conf.BoardSize > KidsBoardSize {
+		return Configuration{}, exception.New(exception.INVALID_CONFIGURATION, fmt.Sprintf("The board size must be at most %v in Quoridor Kids", KidsBoardSize))
+	}
+	if conf.NumberOfFencesPerPawnPlayer > KidsNumberOfFences {
+		return Configuration{}, exception.New(exception.INVALID_CONFIGURATION, fmt.Sprintf("The number of fences must be at most %v in Quoridor Kids", KidsNumberOfFences))
+	}
+	return conf, nil
+}
+
+// MoveOrFenceTwice lets each pawn play two actions, moves or fences, before handing over the turn
+type MoveOrFenceTwice struct {
+	Standard
+}
+
+func (r MoveOrFenceTwice) Apply(g Game, action Action) (Game, error) {
+	g, err := r.play(g, action)
+	if err != nil {
+		return Game{}, err
+	}
+	return r.endAction(g), nil
+}
+
+func (MoveOrFenceTwice) endAction(g Game) Game {
+	g.TurnActions++
+	if g.TurnActions < ActionsPerTwiceTurn {
+		return g
+	}
+	g.TurnActions = 0
+	g.PawnTurn = g.getNextPawnTurn()
+	return g
+}
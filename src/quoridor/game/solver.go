@@ -0,0 +1,198 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+
+	"quoridor/exception"
+)
+
+// MaxSolvedBoardSize is the largest board whose races are solved, the positions of a race growing as the fourth power of the size
+const MaxSolvedBoardSize = 9
+
+// MaxSolvedLayouts is the number of layouts of fences whose solved races are kept, the oldest one being forgotten first
+const MaxSolvedLayouts = 16
+
+// Solution is the outcome of a position under perfect play
+type Solution struct {
+	// Winner is the number of the pawn forcing its win, 0 when each pawn can keep the other one from its goal line forever
+	Winner int `json:"winner"`
+	// Plies is the number of plies before the end of the game, the winner going the shortest way and the loser the longest one
+	Plies int `json:"plies"`
+	// Action is the best action of the current pawn, missing once the game is over
+	Action *Action `json:"action,omitempty"`
+}
+
+// solverNode is a position of the pawn race with the positions it leads to and comes from
+type solverNode struct {
+	game       Game
+	next       []int
+	previous   []int
+	nextLeft   int
+	winner     int
+	plies      int
+	resolved   bool
+	bestAction *Action
+}
+
+// solutionCache keep the solutions of every position of the races already solved, by layout of the board then by race position
+type solutionCache struct {
+	lock    sync.Mutex
+	layouts map[string]map[string]Solution
+	order   []string
+}
+
+var solutions = solutionCache{layouts: make(map[string]map[string]Solution)}
+
+// Solve find the winner of the game under perfect play once no pawn has fences left.
+// The race is solved exactly by walking back from the ends of every reachable position,
+// which takes the jumps and the pawns blocking each other into account where the path distances do not.
+// The solutions of all the positions of the race are kept, so the next actions of the game are not solved again.
+func (g Game) Solve() (Solution, error) {
+	for _, pawn := range g.Pawns {
+		if pawn.FencesLeft > 0 {
+			return Solution{}, exception.New(exception.CONFLICT, "The game cannot be solved while fences are left to play")
+		}
+	}
+	if g.Over {
+		return Solution{g.Winner(), 0, nil}, nil
+	}
+	if len(g.Pawns) != 2 {
+		return Solution{}, exception.New(exception.CONFLICT, "Only the races of two pawns can be solved")
+	}
+	if g.Board.BoardSize > MaxSolvedBoardSize {
+		return Solution{}, exception.New(exception.CONFLICT, fmt.Sprintf("Only the races of boards up to %v squares wide can be solved", MaxSolvedBoardSize))
+	}
+	layout, key := g.layoutKey(), g.raceKey()
+	if solution, found := solutions.get(layout, key); found {
+		return solution, nil
+	}
+	g.History = nil
+	nodes, actions := g.explore()
+	resolve(nodes, actions)
+	solved := make(map[string]Solution, len(nodes))
+	for index, node := range nodes {
+		if !node.resolved {
+			node.bestAction = endlessAction(nodes, actions, index)
+		}
+		solved[node.game.raceKey()] = Solution{node.winner, node.plies, node.bestAction}
+	}
+	solutions.add(layout, solved)
+	return solved[key], nil
+}
+
+func (c *solutionCache) get(layout string, key string) (Solution, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	solution, found := c.layouts[layout][key]
+	return solution, found
+}
+
+func (c *solutionCache) add(layout string, solved map[string]Solution) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, found := c.layouts[layout]; !found {
+		c.order = append(c.order, layout)
+	}
+	c.layouts[layout] = solved
+	if len(c.order) > MaxSolvedLayouts {
+		delete(c.layouts, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// explore get every position reachable from the game, the game first, with the actions leading from each to the next ones
+func (g Game) explore() ([]*solverNode, map[[2]int]Action) {
+	nodes := []*solverNode{{game: g}}
+	indexes := map[string]int{g.raceKey(): 0}
+	actions := make(map[[2]int]Action)
+	for index := 0; index < len(nodes); index++ {
+		node := nodes[index]
+		if node.game.Over {
+			continue
+		}
+		for _, destination := range node.game.GetPossibleMoves() {
+			action := NewMoveAction(destination)
+			next, err := node.game.Apply(action)
+			if err != nil {
+				continue
+			}
+			next.History = nil
+			key := next.raceKey()
+			nextIndex, found := indexes[key]
+			if !found {
+				nextIndex = len(nodes)
+				indexes[key] = nextIndex
+				nodes = append(nodes, &solverNode{game: next})
+			}
+			if _, found := actions[[2]int{index, nextIndex}]; found {
+				continue
+			}
+			actions[[2]int{index, nextIndex}] = action
+			node.next = append(node.next, nextIndex)
+			nodes[nextIndex].previous = append(nodes[nextIndex].previous, index)
+		}
+		node.nextLeft = len(node.next)
+	}
+	return nodes, actions
+}
+
+// resolve walk back from the ends of the game: a position is won as soon as one of its next positions is won
+// by the pawn to play, and lost once all of them are won by the opponent. The positions left are endless.
+func resolve(nodes []*solverNode, actions map[[2]int]Action) {
+	queue := []int{}
+	for index, node := range nodes {
+		if node.game.Over {
+			node.winner, node.resolved = node.game.Winner(), true
+			queue = append(queue, index)
+		}
+	}
+	for len(queue) > 0 {
+		index := queue[0]
+		queue = queue[1:]
+		node := nodes[index]
+		for _, previousIndex := range node.previous {
+			previous := nodes[previousIndex]
+			if previous.resolved {
+				continue
+			}
+			previous.nextLeft--
+			if node.winner != previous.game.PawnTurn && previous.nextLeft > 0 {
+				continue
+			}
+			previous.winner, previous.plies, previous.resolved = node.winner, node.plies+1, true
+			action := actions[[2]int{previousIndex, index}]
+			previous.bestAction = &action
+			queue = append(queue, previousIndex)
+		}
+	}
+}
+
+// endlessAction get an action of the position keeping the race endless, the position being neither won nor lost
+func endlessAction(nodes []*solverNode, actions map[[2]int]Action, index int) *Action {
+	for _, nextIndex := range nodes[index].next {
+		if !nodes[nextIndex].resolved {
+			action := actions[[2]int{index, nextIndex}]
+			return &action
+		}
+	}
+	return nil
+}
+
+// raceKey identify the position of the pawn race, the fences being fixed
+func (g Game) raceKey() string {
+	positions := make([]Position, len(g.Pawns))
+	for index, pawn := range g.Pawns {
+		positions[index] = pawn.Position
+	}
+	return fmt.Sprint(positions, g.PawnTurn, g.TurnActions)
+}
+
+// layoutKey identify what the race is run on: the board, the fences, the rules and the goal lines of the pawns
+func (g Game) layoutKey() string {
+	goals := make([]Direction, len(g.Pawns))
+	for index, pawn := range g.Pawns {
+		goals[index] = pawn.Goal
+	}
+	return fmt.Sprint(g.Board.BoardSize, g.Board.BlockedSquares, g.Fences, g.JumpRule, g.Variant, goals)
+}
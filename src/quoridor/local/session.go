@@ -0,0 +1,119 @@
+// Package local plays games in a terminal without the server, between humans on one keyboard or against the
+// built-in engines.
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"quoridor/engine"
+	"quoridor/game"
+)
+
+// HUMAN is the player of a pawn played on the keyboard
+const HUMAN = "human"
+
+// BotMoveTime is the thinking time of the bots
+const BotMoveTime = 500 * time.Millisecond
+
+// Session is a game played locally, with the players of the pawns and the actions played so far
+type Session struct {
+	Configuration game.Configuration `json:"configuration"`
+	Players       []string           `json:"players"`
+	Actions       []string           `json:"actions"`
+	Game          game.Game          `json:"-"`
+	bots          map[int]engine.Engine
+}
+
+// NewSession start a game, each player being human or a built-in engine. Missing players are human.
+func NewSession(conf game.Configuration, players []string) (*Session, error) {
+	g, err := game.NewGameFromConfiguration(conf)
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{conf, make([]string, len(g.Pawns)), []string{}, g, map[int]engine.Engine{}}
+	for index := range s.Players {
+		s.Players[index] = HUMAN
+		if index < len(players) && players[index] != "" {
+			s.Players[index] = players[index]
+		}
+	}
+	for index, player := range s.Players {
+		if player == HUMAN {
+			continue
+		}
+		if player != engine.RANDOM_ENGINE && player != engine.GREEDY_ENGINE {
+			return nil, fmt.Errorf("the player %v is neither %v, %v nor %v", player, HUMAN, engine.RANDOM_ENGINE, engine.GREEDY_ENGINE)
+		}
+		factory, _ := engine.NewFactory(player)
+		s.bots[index+1], _ = factory()
+	}
+	return s, nil
+}
+
+// Play the action of the current pawn
+func (s *Session) Play(action game.Action) error {
+	g, err := s.Game.Apply(action)
+	if err != nil {
+		return err
+	}
+	s.Game = g
+	s.Actions = append(s.Actions, action.Notation())
+	return nil
+}
+
+// IsBotTurn tell whether the current pawn is played by a bot
+func (s *Session) IsBotTurn() bool {
+	_, found := s.bots[s.Game.PawnTurn]
+	return found && !s.Game.Over
+}
+
+// PlayBot play the action of the bot of the current pawn
+func (s *Session) PlayBot() error {
+	bot, found := s.bots[s.Game.PawnTurn]
+	if !found {
+		return fmt.Errorf("the pawn %v is not played by a bot", s.Game.PawnTurn)
+	}
+	action, err := bot.BestAction(s.Game, BotMoveTime)
+	if err != nil {
+		return err
+	}
+	return s.Play(action)
+}
+
+// Save write the configuration, the players and the actions of the game to the file
+func (s *Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Load read a game saved to the file and replay its actions
+func Load(path string) (*Session, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var saved Session
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	s, err := NewSession(saved.Configuration, saved.Players)
+	if err != nil {
+		return nil, err
+	}
+	for _, notation := range saved.Actions {
+		action, err := game.ParseAction(notation)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Play(action); err != nil {
+			return nil, fmt.Errorf("the action %v cannot be replayed: %v", notation, err)
+		}
+	}
+	return s, nil
+}
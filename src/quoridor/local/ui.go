@@ -0,0 +1,172 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	"quoridor/game"
+)
+
+// Key is a key pressed by a player
+type Key int
+
+const (
+	UP Key = iota
+	DOWN
+	LEFT
+	RIGHT
+	ENTER
+	FENCE_MODE
+	ROTATE
+	SAVE
+	QUIT
+	OTHER
+)
+
+const help = "arrows select, f toggles fences, r rotates the fence, enter plays, s saves, q quits"
+
+// UI selects the squares and the fences with the keyboard
+type UI struct {
+	Session    *Session
+	SavePath   string
+	Message    string
+	cursor     game.Position
+	fenceMode  bool
+	horizontal bool
+}
+
+// NewUI get the UI of the session, saved to the file if any
+func NewUI(s *Session, savePath string) *UI {
+	u := &UI{Session: s, SavePath: savePath, horizontal: true}
+	u.cursor = u.currentPawn().Position
+	return u
+}
+
+func (u *UI) currentPawn() game.Pawn {
+	return u.Session.Game.Pawns[u.Session.Game.PawnTurn-1]
+}
+
+// Handle the key, and tell whether the player quits
+func (u *UI) Handle(key Key) bool {
+	switch key {
+	case UP:
+		u.cursor.Row--
+	case DOWN:
+		u.cursor.Row++
+	case LEFT:
+		u.cursor.Column--
+	case RIGHT:
+		u.cursor.Column++
+	case FENCE_MODE:
+		u.fenceMode = !u.fenceMode
+	case ROTATE:
+		u.horizontal = !u.horizontal
+	case ENTER:
+		u.play()
+	case SAVE:
+		u.save()
+	case QUIT:
+		return true
+	}
+	u.clampCursor()
+	return false
+}
+
+func (u *UI) clampCursor() {
+	last := u.Session.Game.Board.BoardSize - 1
+	if u.fenceMode {
+		last--
+	}
+	u.cursor.Column = clamp(u.cursor.Column, 0, last)
+	u.cursor.Row = clamp(u.cursor.Row, 0, last)
+}
+
+func clamp(value int, min int, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func (u *UI) selectedAction() game.Action {
+	if u.fenceMode {
+		return game.NewFenceAction(game.Fence{u.cursor, u.horizontal})
+	}
+	return game.NewMoveAction(u.cursor)
+}
+
+func (u *UI) play() {
+	if u.Session.Game.Over {
+		u.Message = "The game is over"
+		return
+	}
+	if err := u.Session.Play(u.selectedAction()); err != nil {
+		u.Message = err.Error()
+		return
+	}
+	u.Message = ""
+	u.fenceMode = false
+	if !u.Session.Game.Over {
+		u.cursor = u.currentPawn().Position
+	}
+}
+
+func (u *UI) save() {
+	if u.SavePath == "" {
+		u.Message = "There is no file to save to, start with -save"
+		return
+	}
+	if err := u.Session.Save(u.SavePath); err != nil {
+		u.Message = err.Error()
+		return
+	}
+	u.Message = "Saved to " + u.SavePath
+}
+
+// PlayBots play the actions of the bots until a human has to play or the game is over
+func (u *UI) PlayBots() error {
+	for u.Session.IsBotTurn() {
+		if err := u.Session.PlayBot(); err != nil {
+			return err
+		}
+	}
+	if !u.Session.Game.Over {
+		u.cursor = u.currentPawn().Position
+	}
+	return nil
+}
+
+// Render draw the board with the coordinates, the selection and the legal moves of a human, then the status
+func (u *UI) Render() string {
+	g := u.Session.Game
+	selection := u.selectedAction()
+	options := game.RenderOptions{Coordinates: true, LegalMoves: !u.Session.IsBotTurn(), Color: true, Selection: &selection}
+	var builder strings.Builder
+	builder.WriteString(g.Render(options))
+	builder.WriteString("\n")
+	for index, pawn := range g.Pawns {
+		marker := " "
+		if index+1 == g.PawnTurn && !g.Over {
+			marker = ">"
+		}
+		fmt.Fprintf(&builder, "%v %v (%v), %v fences left\n", marker, index+1, u.Session.Players[index], pawn.FencesLeft)
+	}
+	switch {
+	case g.Over:
+		// the board tells the winner
+	case u.fenceMode && u.horizontal:
+		builder.WriteString("Adding a horizontal fence\n")
+	case u.fenceMode:
+		builder.WriteString("Adding a vertical fence\n")
+	default:
+		builder.WriteString("Moving the pawn\n")
+	}
+	builder.WriteString(help + "\n")
+	if u.Message != "" {
+		builder.WriteString(u.Message + "\n")
+	}
+	return builder.String()
+}
@@ -1,11 +1,28 @@
 package main
 
 import (
+	"log"
+	"os"
+
+	"quoridor/auth"
+	"quoridor/bot"
+	"quoridor/matchmaking"
+	"quoridor/rating"
 	"quoridor/server"
 	"quoridor/storage"
+	"quoridor/tournament"
 )
 
 func main() {
+	auth.Configure(os.Getenv(auth.SecretEnvironmentVariable))
+	if err := bot.Configure(os.Getenv(bot.EnginesEnvironmentVariable)); err != nil {
+		log.Fatal(err)
+	}
+	bot.ConfigureWebhooks(os.Getenv(bot.WebhookHostsEnvironmentVariable))
 	storage.Init()
+	matchmaking.Init()
+	rating.Init()
+	server.InitOpenings()
+	tournament.Init()
 	server.Start()
 }
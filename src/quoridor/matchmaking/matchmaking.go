@@ -0,0 +1,247 @@
+package matchmaking
+
+import (
+	"sync"
+	"time"
+
+	"quoridor/auth"
+	"quoridor/controller"
+	"quoridor/exception"
+	"quoridor/game"
+
+	"github.com/lithammer/shortuuid"
+)
+
+// TicketTTL is how long a ticket is kept once its player stops waiting for it
+const TicketTTL = time.Minute
+
+// Preferences of a player entering the queue, the zero values match any other player.
+// The time control only pairs the players, the games having no clocks yet.
+type Preferences struct {
+	Name        string `json:"name"`
+	BoardSize   int    `json:"boardSize"`
+	TimeControl string `json:"timeControl"`
+	// UserID is the logged in user, seated in the game to rate it
+	UserID string `json:"-"`
+}
+
+// Match is the seat given to a player once paired
+type Match struct {
+	GameID string `json:"gameId"`
+	Number int    `json:"number"`
+	Token  string `json:"token"`
+}
+
+type ticket struct {
+	id          string
+	preferences Preferences
+	match       Match
+	matched     chan struct{}
+	expires     time.Time
+}
+
+// Matchmaker pairs the compatible players of its queue
+type Matchmaker struct {
+	// TTL is how long a ticket is kept once its player stops waiting for it, TicketTTL by default
+	TTL     time.Duration
+	mutex   sync.Mutex
+	queue   []*ticket
+	tickets map[string]*ticket
+	wake    chan struct{}
+}
+
+func NewMatchmaker() *Matchmaker {
+	return &Matchmaker{TTL: TicketTTL, tickets: make(map[string]*ticket), wake: make(chan struct{}, 1)}
+}
+
+func (p Preferences) isCompatible(other Preferences) bool {
+	sameBoard := p.BoardSize == 0 || other.BoardSize == 0 || p.BoardSize == other.BoardSize
+	sameTimeControl := p.TimeControl == "" || other.TimeControl == "" || p.TimeControl == other.TimeControl
+	sameUser := p.UserID != "" && p.UserID == other.UserID
+	return sameBoard && sameTimeControl && !sameUser
+}
+
+func (t *ticket) isMatched() bool {
+	select {
+	case <-t.matched:
+		return true
+	default:
+		return false
+	}
+}
+
+// Enqueue add the player to the queue and get the identifier of its ticket
+func (m *Matchmaker) Enqueue(preferences Preferences) (string, error) {
+	if preferences.BoardSize != 0 {
+		if _, err := game.NewBoard(preferences.BoardSize); err != nil {
+			return "", err
+		}
+	}
+	m.mutex.Lock()
+	t := &ticket{shortuuid.New(), preferences, Match{}, make(chan struct{}), time.Now().Add(m.TTL)}
+	m.queue = append(m.queue, t)
+	m.tickets[t.id] = t
+	m.mutex.Unlock()
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+	return t.id, nil
+}
+
+// Wait wait at most the timeout for the ticket to be matched, the ticket being kept for TTL after the wait
+// and removed once its match is given
+func (m *Matchmaker) Wait(ticketID string, timeout time.Duration) (Match, bool, error) {
+	m.mutex.Lock()
+	t, found := m.tickets[ticketID]
+	if found {
+		t.expires = time.Now().Add(timeout + m.TTL)
+	}
+	m.mutex.Unlock()
+	if !found {
+		return Match{}, false, exception.New(exception.NOT_FOUND, "The ticket does not exist")
+	}
+	select {
+	case <-t.matched:
+		m.mutex.Lock()
+		delete(m.tickets, ticketID)
+		m.mutex.Unlock()
+		return t.match, true, nil
+	case <-time.After(timeout):
+		return Match{}, false, nil
+	}
+}
+
+// Cancel remove the ticket from the queue
+func (m *Matchmaker) Cancel(ticketID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	t, found := m.tickets[ticketID]
+	if !found {
+		return exception.New(exception.NOT_FOUND, "The ticket does not exist")
+	}
+	if t.isMatched() {
+		return exception.New(exception.CONFLICT, "The ticket is already matched")
+	}
+	delete(m.tickets, ticketID)
+	m.removeFromQueue(t)
+	return nil
+}
+
+// Run pair the players each time a new one enters the queue and remove the expired tickets, until stop is closed
+func (m *Matchmaker) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(m.TTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-m.wake:
+			m.pairAll()
+		case <-ticker.C:
+			m.mutex.Lock()
+			m.evict(time.Now())
+			m.mutex.Unlock()
+		}
+	}
+}
+
+// evict remove the tickets whose players have stopped waiting for too long, matched or not
+func (m *Matchmaker) evict(now time.Time) {
+	for id, t := range m.tickets {
+		if now.After(t.expires) {
+			delete(m.tickets, id)
+			m.removeFromQueue(t)
+		}
+	}
+}
+
+func (m *Matchmaker) pairAll() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.evict(time.Now())
+	for i := 0; i < len(m.queue); i++ {
+		for j := i + 1; j < len(m.queue); j++ {
+			first, second := m.queue[i], m.queue[j]
+			if !first.preferences.isCompatible(second.preferences) {
+				continue
+			}
+			if err := startGame(first, second); err != nil {
+				continue
+			}
+			m.removeFromQueue(first)
+			m.removeFromQueue(second)
+			i--
+			break
+		}
+	}
+}
+
+func (m *Matchmaker) removeFromQueue(t *ticket) {
+	for index, queued := range m.queue {
+		if queued == t {
+			m.queue = append(m.queue[:index], m.queue[index+1:]...)
+			return
+		}
+	}
+}
+
+// startGame create the game of the two players and seat them in their order in the queue,
+// the game being deleted if they cannot both be seated
+func startGame(first *ticket, second *ticket) error {
+	boardSize := first.preferences.BoardSize
+	if boardSize == 0 {
+		boardSize = second.preferences.BoardSize
+	}
+	if boardSize == 0 {
+		boardSize = game.DefaultBoardSize
+	}
+	conf := game.Configuration{BoardSize: boardSize, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences}
+	g, err := gamecontroller.HostGame(conf, first.preferences.Name)
+	if err != nil {
+		return err
+	}
+	matches := make([]Match, 2)
+	for index, t := range []*ticket{first, second} {
+		seat, err := gamecontroller.TakeSeat(g.ID, t.preferences.UserID)
+		if err != nil {
+			gamecontroller.DeleteGame(g.ID)
+			return err
+		}
+		token, err := auth.Issue(g.ID, seat.Number)
+		if err != nil {
+			gamecontroller.DeleteGame(g.ID)
+			return err
+		}
+		matches[index] = Match{g.ID, seat.Number, token}
+	}
+	first.match, second.match = matches[0], matches[1]
+	close(first.matched)
+	close(second.matched)
+	return nil
+}
+
+var defaultMatchmaker *Matchmaker
+var stopDefault chan struct{}
+
+// Init start the default matchmaker, replacing the previous one
+func Init() {
+	if stopDefault != nil {
+		close(stopDefault)
+	}
+	defaultMatchmaker = NewMatchmaker()
+	stopDefault = make(chan struct{})
+	go defaultMatchmaker.Run(stopDefault)
+}
+
+func Enqueue(preferences Preferences) (string, error) {
+	return defaultMatchmaker.Enqueue(preferences)
+}
+
+func Wait(ticketID string, timeout time.Duration) (Match, bool, error) {
+	return defaultMatchmaker.Wait(ticketID, timeout)
+}
+
+func Cancel(ticketID string) error {
+	return defaultMatchmaker.Cancel(ticketID)
+}
@@ -0,0 +1,194 @@
+package openings
+
+import (
+	"sort"
+	"sync"
+
+	"quoridor/game"
+)
+
+// Book defaults
+const (
+	// Plies is the number of plies of each game aggregated in the book
+	Plies = 12
+	// MinGames is the number of games a move must have been played in to be suggested
+	MinGames = 3
+)
+
+// Stats are the outcomes of the games for the player who moved
+type Stats struct {
+	Games  int `json:"games"`
+	Wins   int `json:"wins"`
+	Draws  int `json:"draws"`
+	Losses int `json:"losses"`
+}
+
+// Score get the share of the points won, a draw being worth half a win
+func (s Stats) Score() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return (float64(s.Wins) + float64(s.Draws)/2) / float64(s.Games)
+}
+
+func (s Stats) add(mover int, winner int) Stats {
+	s.Games++
+	switch winner {
+	case 0:
+		s.Draws++
+	case mover:
+		s.Wins++
+	default:
+		s.Losses++
+	}
+	return s
+}
+
+// MoveStats are the outcomes of an action played from a position, with the position it leads to
+type MoveStats struct {
+	Action   game.Action `json:"action"`
+	Notation string      `json:"notation"`
+	Next     string      `json:"next"`
+	Stats
+}
+
+// Opening is a position of the book with the actions played from it, the most played first
+type Opening struct {
+	Position string      `json:"position"`
+	Ply      int         `json:"ply"`
+	Games    int         `json:"games"`
+	Moves    []MoveStats `json:"moves"`
+}
+
+type entry struct {
+	ply   int
+	games int
+	moves map[string]MoveStats
+}
+
+// Book aggregates the first plies of the finished games, by canonical position
+type Book struct {
+	plies   int
+	lock    sync.RWMutex
+	entries map[string]*entry
+}
+
+// Default is the book fed with the games played on the server
+var Default = NewBook(Plies)
+
+// NewBook create an empty book aggregating the number of plies of each game
+func NewBook(plies int) *Book {
+	return &Book{plies: plies, entries: make(map[string]*entry)}
+}
+
+// Add aggregate the positions of a game from its start, the last one ending the game won by the winner, 0 for a draw
+func (b *Book) Add(positions []game.Game, winner int) {
+	if len(positions) == 0 {
+		return
+	}
+	history := positions[len(positions)-1].History
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for ply := 0; ply < b.plies && ply < len(history) && ply+1 < len(positions); ply++ {
+		position := positions[ply]
+		hash, mirrored := Hash(position)
+		action := history[ply].Action
+		if mirrored {
+			action = mirrorAction(action, position.Board.BoardSize)
+		}
+		next, _ := Hash(positions[ply+1])
+		e, found := b.entries[hash]
+		if !found {
+			e = &entry{ply, 0, make(map[string]MoveStats)}
+			b.entries[hash] = e
+		}
+		e.games++
+		move, found := e.moves[action.Notation()]
+		if !found {
+			move = MoveStats{action, action.Notation(), next, Stats{}}
+		}
+		move.Stats = move.Stats.add(history[ply].Number, winner)
+		e.moves[move.Notation] = move
+	}
+}
+
+// Get get the position of the hash, the actions as played in the canonical position
+func (b *Book) Get(hash string) (Opening, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	e, found := b.entries[hash]
+	if !found {
+		return Opening{}, false
+	}
+	return e.opening(hash), true
+}
+
+// Starts get the starting positions of the games of the book, the most played first
+func (b *Book) Starts() []Opening {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	starts := []Opening{}
+	for hash, e := range b.entries {
+		if e.ply == 0 {
+			starts = append(starts, e.opening(hash))
+		}
+	}
+	sort.SliceStable(starts, func(i, j int) bool {
+		if starts[i].Games != starts[j].Games {
+			return starts[i].Games > starts[j].Games
+		}
+		return starts[i].Position < starts[j].Position
+	})
+	return starts
+}
+
+// Lookup get the book entry of the game, the actions as they are played in the game
+func (b *Book) Lookup(g game.Game) (Opening, bool) {
+	hash, mirrored := Hash(g)
+	opening, found := b.Get(hash)
+	if !found || !mirrored {
+		return opening, found
+	}
+	for index, move := range opening.Moves {
+		move.Action = mirrorAction(move.Action, g.Board.BoardSize)
+		move.Notation = move.Action.Notation()
+		opening.Moves[index] = move
+	}
+	return opening, true
+}
+
+// Suggest get the legal action with the best score among the ones played in at least MinGames games
+func (b *Book) Suggest(g game.Game) (game.Action, bool) {
+	opening, found := b.Lookup(g)
+	if !found {
+		return game.Action{}, false
+	}
+	var best *MoveStats
+	for index := range opening.Moves {
+		move := &opening.Moves[index]
+		if move.Games < MinGames || (best != nil && move.Score() <= best.Score()) {
+			continue
+		}
+		if _, err := g.Apply(move.Action); err == nil {
+			best = move
+		}
+	}
+	if best == nil {
+		return game.Action{}, false
+	}
+	return best.Action, true
+}
+
+func (e *entry) opening(hash string) Opening {
+	moves := []MoveStats{}
+	for _, move := range e.moves {
+		moves = append(moves, move)
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		if moves[i].Games != moves[j].Games {
+			return moves[i].Games > moves[j].Games
+		}
+		return moves[i].Notation < moves[j].Notation
+	})
+	return Opening{hash, e.ply, e.games, moves}
+}
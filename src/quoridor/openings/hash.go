@@ -0,0 +1,94 @@
+package openings
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"quoridor/game"
+)
+
+// Hash get the canonical hash of the position, shared by the position and its mirror image
+// across the middle row, and tell whether the position had to be mirrored to get it
+func Hash(g game.Game) (string, bool) {
+	description := describe(g, false)
+	mirrored := describe(g, true)
+	if mirrored < description {
+		return digest(mirrored), true
+	}
+	return digest(description), false
+}
+
+func digest(description string) string {
+	hash := fnv.New64a()
+	hash.Write([]byte(description))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// describe get every part of the position deciding how the game goes on, the rows upside down when mirrored
+func describe(g game.Game, mirror bool) string {
+	size := g.Board.BoardSize
+	words := []string{
+		"size", fmt.Sprint(size),
+		"variant", g.Variant,
+		"jump", fmt.Sprint(g.JumpRule),
+		"turn", fmt.Sprint(g.PawnTurn),
+		"actions", fmt.Sprint(g.TurnActions),
+	}
+	for _, pawn := range g.Pawns {
+		position, goal := pawn.Position, pawn.Goal
+		if mirror {
+			position, goal = mirrorPosition(position, size), mirrorDirection(goal)
+		}
+		words = append(words, "pawn", position.Notation(), fmt.Sprint(goal), fmt.Sprint(pawn.FencesLeft))
+	}
+	blocked := []string{}
+	for _, square := range g.Board.BlockedSquares {
+		if mirror {
+			square = mirrorPosition(square, size)
+		}
+		blocked = append(blocked, "blocked "+square.Notation())
+	}
+	sort.Strings(blocked)
+	fences := []string{}
+	for _, fence := range g.Fences {
+		if mirror {
+			fence = mirrorFence(fence, size)
+		}
+		fences = append(fences, "fence "+fence.Notation())
+	}
+	sort.Strings(fences)
+	words = append(append(words, blocked...), fences...)
+	return strings.Join(words, " ")
+}
+
+func mirrorPosition(p game.Position, size int) game.Position {
+	return game.Position{p.Column, size - 1 - p.Row}
+}
+
+// mirrorFence get the fence mirrored, a fence lying across two rows from its north west square
+func mirrorFence(f game.Fence, size int) game.Fence {
+	return game.Fence{game.Position{f.NWSquare.Column, size - 2 - f.NWSquare.Row}, f.Horizontal}
+}
+
+func mirrorDirection(d game.Direction) game.Direction {
+	switch d {
+	case game.NORTH:
+		return game.SOUTH
+	case game.SOUTH:
+		return game.NORTH
+	}
+	return d
+}
+
+// mirrorAction get the action played in the mirror image of the position
+func mirrorAction(a game.Action, size int) game.Action {
+	if a.Type == game.FENCE_ACTION && a.Fence != nil {
+		return game.NewFenceAction(mirrorFence(*a.Fence, size))
+	}
+	if a.Position != nil {
+		return game.NewMoveAction(mirrorPosition(*a.Position, size))
+	}
+	return a
+}
@@ -0,0 +1,94 @@
+package rating
+
+import (
+	"math"
+)
+
+// Glicko-2 system constants, from Glickman's "Example of the Glicko-2 system"
+const (
+	DefaultRating     = 1500.0
+	DefaultDeviation  = 350.0
+	DefaultVolatility = 0.06
+	Tau               = 0.5
+	glickoScale       = 173.7178
+	convergence       = 0.000001
+)
+
+// Rating is the strength of a player with its uncertainty
+type Rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Result is the score of a game against an opponent, 1 for a win and 0 for a loss
+type Result struct {
+	Opponent Rating
+	Score    float64
+}
+
+func NewRating() Rating {
+	return Rating{DefaultRating, DefaultDeviation, DefaultVolatility}
+}
+
+// Update get the rating of the player after a rating period made of the results
+func Update(player Rating, results []Result) Rating {
+	mu := (player.Rating - DefaultRating) / glickoScale
+	phi := player.Deviation / glickoScale
+	if len(results) == 0 {
+		return Rating{player.Rating, math.Sqrt(phi*phi+player.Volatility*player.Volatility) * glickoScale, player.Volatility}
+	}
+	inverseVariance := 0.0
+	improvement := 0.0
+	for _, result := range results {
+		opponentMu := (result.Opponent.Rating - DefaultRating) / glickoScale
+		impact := g(result.Opponent.Deviation / glickoScale)
+		expected := 1 / (1 + math.Exp(-impact*(mu-opponentMu)))
+		inverseVariance += impact * impact * expected * (1 - expected)
+		improvement += impact * (result.Score - expected)
+	}
+	variance := 1 / inverseVariance
+	delta := variance * improvement
+	volatility := newVolatility(phi, player.Volatility, variance, delta)
+	phiStar := math.Sqrt(phi*phi + volatility*volatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	newMu := mu + newPhi*newPhi*improvement
+	return Rating{newMu*glickoScale + DefaultRating, newPhi * glickoScale, volatility}
+}
+
+func g(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+// newVolatility find the new volatility with the Illinois algorithm
+func newVolatility(phi float64, volatility float64, variance float64, delta float64) float64 {
+	a := math.Log(volatility * volatility)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + variance + ex
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(Tau*Tau)
+	}
+	lower := a
+	var upper float64
+	if delta*delta > phi*phi+variance {
+		upper = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*Tau) < 0 {
+			k++
+		}
+		upper = a - k*Tau
+	}
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > convergence {
+		c := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fc := f(c)
+		if fc*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower = fLower / 2
+		}
+		upper, fUpper = c, fc
+	}
+	return math.Exp(lower / 2)
+}
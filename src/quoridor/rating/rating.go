@@ -0,0 +1,115 @@
+package rating
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"quoridor/controller"
+	"quoridor/storage"
+	"quoridor/users"
+)
+
+// ratingPrefix keeps the ratings apart from the games in the storage
+const ratingPrefix = "rating:"
+
+// Change is the rating of a player after a game
+type Change struct {
+	GameID string `json:"gameId"`
+	Rating
+	At time.Time `json:"at"`
+}
+
+// PlayerRating is the rating of a user on a board size, each board size being rated apart
+type PlayerRating struct {
+	UserID    string `json:"userId"`
+	Name      string `json:"name"`
+	BoardSize int    `json:"boardSize"`
+	Rating
+	Games   int      `json:"games"`
+	History []Change `json:"history,omitempty"`
+}
+
+var register sync.Once
+
+// recording avoids losing an update when two games of a user end at once
+var recording sync.Mutex
+
+// Init rate the games of the users as soon as they end
+func Init() {
+	register.Do(func() {
+		gamecontroller.OnGameOver(Record)
+	})
+}
+
+func key(userID string, boardSize int) string {
+	return fmt.Sprintf("%v%v:%v", ratingPrefix, userID, boardSize)
+}
+
+// Record update the ratings of the two users of the game, each game being a rating period.
+// Games with an anonymous player are not rated, nor the forks as they may start from a decided position.
+func Record(result gamecontroller.GameResult) {
+	if len(result.Users) != 2 || result.Users[0] == "" || result.Users[1] == "" || result.Winner == 0 {
+		return
+	}
+	if forkedFrom, err := gamecontroller.GetForkedFrom(result.GameID); err == nil && forkedFrom != nil {
+		return
+	}
+	recording.Lock()
+	defer recording.Unlock()
+	players := make([]PlayerRating, 2)
+	for index, userID := range result.Users {
+		player, err := GetRating(userID, result.BoardSize)
+		if err != nil {
+			return
+		}
+		players[index] = player
+	}
+	now := time.Now()
+	for index, player := range players {
+		opponent := players[1-index]
+		score := 0.0
+		if result.Winner == index+1 {
+			score = 1
+		}
+		player.Rating = Update(player.Rating, []Result{{opponent.Rating, score}})
+		player.Games++
+		player.History = append(player.History, Change{result.GameID, player.Rating, now})
+		storage.Set(key(player.UserID, player.BoardSize), player)
+	}
+}
+
+// GetRating get the rating of the user on the board size, the default rating if the user has not played yet
+func GetRating(userID string, boardSize int) (PlayerRating, error) {
+	if value, found := storage.Get(key(userID, boardSize)); found {
+		return value.(PlayerRating), nil
+	}
+	user, err := users.GetUser(userID)
+	if err != nil {
+		return PlayerRating{}, err
+	}
+	return PlayerRating{user.ID, user.Name, boardSize, NewRating(), 0, []Change{}}, nil
+}
+
+// Leaderboard get the ratings, without history, the best first. A zero board size matches every board.
+func Leaderboard(boardSize int) []PlayerRating {
+	ratings := []PlayerRating{}
+	for _, value := range storage.List() {
+		player, isRating := value.(PlayerRating)
+		if isRating && (boardSize == 0 || player.BoardSize == boardSize) {
+			player.History = nil
+			ratings = append(ratings, player)
+		}
+	}
+	sort.SliceStable(ratings, func(i, j int) bool {
+		if ratings[i].Rating.Rating != ratings[j].Rating.Rating {
+			return ratings[i].Rating.Rating > ratings[j].Rating.Rating
+		}
+		if ratings[i].Deviation != ratings[j].Deviation {
+			return ratings[i].Deviation < ratings[j].Deviation
+		}
+		return ratings[i].Name < ratings[j].Name
+	})
+	return ratings
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+func registerAnalysisRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{gameId}/solution", solutionHandler).Methods("GET")
+}
+
+// solutionHandler get the outcome under perfect play of the game, as it was after the plies of the query if any,
+// once no pawn has fences left
+func solutionHandler(w http.ResponseWriter, r *http.Request) {
+	g, err := getRequestedGame(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	solution, err := g.Solve()
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, solution)
+}
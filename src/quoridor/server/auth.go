@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"quoridor/auth"
+	"quoridor/controller"
+	"quoridor/exception"
+	"quoridor/server/request"
+	"quoridor/server/response"
+)
+
+type contextKey string
+
+const claimsKey contextKey = "claims"
+
+// authenticatePlayer verify the signed player token of the Authorization header before calling the handler,
+// the token must be issued for the game of the path
+func authenticatePlayer(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := auth.Verify(request.GetBearerToken(r))
+		if err != nil {
+			response.SendError(w, err)
+			return
+		}
+		if claims.GameID != request.GetGameID(r) {
+			response.SendError(w, exception.New(exception.FORBIDDEN, "The token is not for this game"))
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey, claims)))
+	}
+}
+
+// getPlayerNumber get the seat of the player authenticated by authenticatePlayer
+func getPlayerNumber(r *http.Request) int {
+	claims, _ := r.Context().Value(claimsKey).(auth.Claims)
+	return claims.Number
+}
+
+// newSeatToken sign the token proving the seat
+func newSeatToken(gameID string, seat gamecontroller.Seat) (SeatToken, error) {
+	token, err := auth.Issue(gameID, seat.Number)
+	if err != nil {
+		return SeatToken{}, err
+	}
+	return SeatToken{seat.Number, token}, nil
+}
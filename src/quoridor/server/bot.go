@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/bot"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+func registerBotRoutes(router *mux.Router) {
+	router.HandleFunc("/engines", listEnginesHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}/bots", addBotHandler).Methods("POST")
+}
+
+func listEnginesHandler(w http.ResponseWriter, r *http.Request) {
+	response.SendOK(w, bot.Engines())
+}
+
+func addBotHandler(w http.ResponseWriter, r *http.Request) {
+	botRequest, err := request.GetBotRequest(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	userID, err := getOptionalUserID(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	added, err := bot.AddRequested(request.GetGameID(r), botRequest, userID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, added)
+}
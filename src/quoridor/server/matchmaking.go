@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/matchmaking"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+// Searching is the status of a ticket still waiting for an opponent
+const Searching = "searching"
+
+// Ticket is the place of a player in the matchmaking queue
+type Ticket struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func registerMatchmakingRoutes(router *mux.Router) {
+	router.HandleFunc("/matchmaking", enqueueHandler).Methods("POST")
+	router.HandleFunc("/matchmaking/{ticketId}", waitMatchHandler).Methods("GET")
+	router.HandleFunc("/matchmaking/{ticketId}", cancelTicketHandler).Methods("DELETE")
+}
+
+func enqueueHandler(w http.ResponseWriter, r *http.Request) {
+	preferences, err := request.GetMatchmakingPreferences(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	preferences.UserID, err = getOptionalUserID(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	ticketID, err := matchmaking.Enqueue(preferences)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendAccepted(w, Ticket{ticketID, Searching})
+}
+
+// waitMatchHandler long poll the ticket, answering as soon as the player is paired or when the wait is over
+func waitMatchHandler(w http.ResponseWriter, r *http.Request) {
+	wait, err := request.GetWait(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	ticketID := request.GetTicketID(r)
+	match, matched, err := matchmaking.Wait(ticketID, wait)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	if !matched {
+		response.SendAccepted(w, Ticket{ticketID, Searching})
+		return
+	}
+	response.SendOK(w, match)
+}
+
+func cancelTicketHandler(w http.ResponseWriter, r *http.Request) {
+	err := matchmaking.Cancel(request.GetTicketID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendNoContent(w)
+}
@@ -0,0 +1,1239 @@
+package openapi
+
+import (
+	"encoding/json"
+)
+
+// Document is the OpenAPI 3 description of the API
+const Document = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Quoridor API",
+    "description": "The request bodies are at most 64 KB, a larger one being answered with the status 413",
+    "version": "1.18.0"
+  },
+  "paths": {
+    "/": {
+      "get": {
+        "summary": "Welcome message",
+        "responses": {
+          "200": {"description": "Welcome", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Message"}}}}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "responses": {
+          "200": {"description": "The OpenAPI document"}
+        }
+      }
+    },
+    "/v1/games": {
+      "get": {
+        "summary": "List the games of the lobby, the games waiting for a player by default",
+        "parameters": [{"$ref": "#/components/parameters/Status"}, {"$ref": "#/components/parameters/BoardSize"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The games", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSummaryPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Create a game, the default configuration is used without body",
+        "parameters": [{"$ref": "#/components/parameters/Creator"}],
+        "requestBody": {
+          "required": false,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Configuration"}}}
+        },
+        "responses": {
+          "201": {"$ref": "#/components/responses/GameResource"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}": {
+      "get": {
+        "summary": "Get the game, or the game as it was after a number of plies, as a text board or an SVG or PNG image drawn with the render options when accepted",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}, {"$ref": "#/components/parameters/Coordinates"}, {"$ref": "#/components/parameters/LegalMoves"}, {"$ref": "#/components/parameters/LastMove"}, {"$ref": "#/components/parameters/FencesLeft"}, {"$ref": "#/components/parameters/ASCII"}, {"$ref": "#/components/parameters/Color"}],
+        "responses": {
+          "200": {"$ref": "#/components/responses/GameResource"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/players": {
+      "get": {
+        "summary": "Get the players of the game",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}],
+        "responses": {
+          "200": {"description": "The players", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/PlayerResource"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Join the game and get the seat and the token of the player, bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Session"}],
+        "responses": {
+          "201": {"description": "The seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/SeatToken"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/resume": {
+      "post": {
+        "summary": "Get the seat of the user and the token of the player, to play from another device",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/RequiredSession"}],
+        "responses": {
+          "200": {"description": "The seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/SeatToken"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/users": {
+      "post": {
+        "summary": "Register a user",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Credentials"}}}},
+        "responses": {
+          "201": {"description": "The user", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/users/me": {
+      "get": {
+        "summary": "Get the logged in user",
+        "parameters": [{"$ref": "#/components/parameters/RequiredSession"}],
+        "responses": {
+          "200": {"description": "The user", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/users/me/games": {
+      "get": {
+        "summary": "Get a page of the games where the logged in user has a seat, the most recent first",
+        "parameters": [{"$ref": "#/components/parameters/RequiredSession"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The games", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PlayedGamePage"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/sessions": {
+      "post": {
+        "summary": "Log in and get the bearer token of the session",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Credentials"}}}},
+        "responses": {
+          "201": {"description": "The session", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Session"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Log out",
+        "parameters": [{"$ref": "#/components/parameters/RequiredSession"}],
+        "responses": {
+          "204": {"description": "Logged out"},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/actions": {
+      "post": {
+        "summary": "Play a pawn move or a fence addition for the player",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Authorization"}],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Action"}}}
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/GameResource"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/legal-actions": {
+      "get": {
+        "summary": "Get a page of the actions the current player can play",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The actions", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ActionPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/matchmaking": {
+      "post": {
+        "summary": "Enter the matchmaking queue, the empty preferences match any opponent, the seat being bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": false, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/MatchmakingPreferences"}}}},
+        "responses": {
+          "202": {"description": "The ticket in the queue", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Ticket"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/matchmaking/{ticketId}": {
+      "get": {
+        "summary": "Wait for an opponent, answering as soon as the game is created or when the wait is over",
+        "parameters": [{"$ref": "#/components/parameters/TicketId"}, {"$ref": "#/components/parameters/Wait"}],
+        "responses": {
+          "200": {"description": "The seat in the created game", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Match"}}}},
+          "202": {"description": "Still searching", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Ticket"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Leave the matchmaking queue",
+        "parameters": [{"$ref": "#/components/parameters/TicketId"}],
+        "responses": {
+          "204": {"description": "Left the queue"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/matchmaking": {
+      "post": {
+        "summary": "Enter the matchmaking queue, the empty preferences match any opponent, the seat being bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": false, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/MatchmakingPreferences"}}}},
+        "responses": {
+          "202": {"description": "The ticket in the queue", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Ticket"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/matchmaking/{ticketId}": {
+      "get": {
+        "summary": "Wait for an opponent, answering as soon as the game is created or when the wait is over",
+        "parameters": [{"$ref": "#/components/parameters/TicketId"}, {"$ref": "#/components/parameters/Wait"}],
+        "responses": {
+          "200": {"description": "The seat in the created game", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Match"}}}},
+          "202": {"description": "Still searching", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Ticket"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Leave the matchmaking queue",
+        "parameters": [{"$ref": "#/components/parameters/TicketId"}],
+        "responses": {
+          "204": {"description": "Left the queue"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/players/{userId}/rating": {
+      "get": {
+        "summary": "Get the Glicko-2 rating of the user on a board size, with its history",
+        "parameters": [{"$ref": "#/components/parameters/UserId"}, {"$ref": "#/components/parameters/RatedBoardSize"}],
+        "responses": {
+          "200": {"description": "The rating", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PlayerRating"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/leaderboard": {
+      "get": {
+        "summary": "Get a page of the ratings, the best first",
+        "parameters": [{"$ref": "#/components/parameters/BoardSize"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The ratings", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PlayerRatingPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/players/{userId}/rating": {
+      "get": {
+        "summary": "Get the Glicko-2 rating of the user on a board size, with its history",
+        "parameters": [{"$ref": "#/components/parameters/UserId"}, {"$ref": "#/components/parameters/RatedBoardSize"}],
+        "responses": {
+          "200": {"description": "The rating", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PlayerRating"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/leaderboard": {
+      "get": {
+        "summary": "Get a page of the ratings, the best first",
+        "parameters": [{"$ref": "#/components/parameters/BoardSize"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The ratings", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PlayerRatingPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments": {
+      "post": {
+        "summary": "Create a tournament and open its registrations",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentSettings"}}}},
+        "responses": {
+          "201": {"description": "The tournament with the token of its organizer", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentCreation"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "get": {
+        "summary": "Get a page of the tournaments, the most recent first",
+        "parameters": [{"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The tournaments", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments/{tournamentId}": {
+      "get": {
+        "summary": "Get the tournament with its rounds",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}],
+        "responses": {
+          "200": {"description": "The tournament", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Tournament"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments/{tournamentId}/participants": {
+      "post": {
+        "summary": "Register a participant, bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ParticipantName"}}}},
+        "responses": {
+          "201": {"description": "The participant with its token", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Registration"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments/{tournamentId}/start": {
+      "post": {
+        "summary": "Close the registrations and create the games of the first round",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/OrganizerToken"}],
+        "responses": {
+          "200": {"description": "The tournament", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Tournament"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments/{tournamentId}/standings": {
+      "get": {
+        "summary": "Get the standings, ties being broken by the Buchholz then the Sonneborn-Berger scores",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}],
+        "responses": {
+          "200": {"description": "The standings", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Standing"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/tournaments/{tournamentId}/seat": {
+      "get": {
+        "summary": "Get the game of the current round of the participant with the token to play",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/ParticipantToken"}],
+        "responses": {
+          "200": {"description": "The seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSeat"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments": {
+      "post": {
+        "summary": "Create a tournament and open its registrations",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentSettings"}}}},
+        "responses": {
+          "201": {"description": "The tournament with the token of its organizer", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentCreation"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "get": {
+        "summary": "Get a page of the tournaments, the most recent first",
+        "parameters": [{"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The tournaments", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TournamentPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments/{tournamentId}": {
+      "get": {
+        "summary": "Get the tournament with its rounds",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}],
+        "responses": {
+          "200": {"description": "The tournament", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Tournament"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments/{tournamentId}/participants": {
+      "post": {
+        "summary": "Register a participant, bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ParticipantName"}}}},
+        "responses": {
+          "201": {"description": "The participant with its token", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Registration"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments/{tournamentId}/start": {
+      "post": {
+        "summary": "Close the registrations and create the games of the first round",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/OrganizerToken"}],
+        "responses": {
+          "200": {"description": "The tournament", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Tournament"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments/{tournamentId}/standings": {
+      "get": {
+        "summary": "Get the standings, ties being broken by the Buchholz then the Sonneborn-Berger scores",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}],
+        "responses": {
+          "200": {"description": "The standings", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Standing"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/tournaments/{tournamentId}/seat": {
+      "get": {
+        "summary": "Get the game of the current round of the participant with the token to play",
+        "parameters": [{"$ref": "#/components/parameters/TournamentId"}, {"$ref": "#/components/parameters/ParticipantToken"}],
+        "responses": {
+          "200": {"description": "The seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSeat"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/engines": {
+      "get": {
+        "summary": "List the engines which can be seated as bots",
+        "responses": {
+          "200": {"description": "The names of the engines", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "string"}}}}}
+        }
+      }
+    },
+    "/v1/games/{gameId}/bots": {
+      "post": {
+        "summary": "Seat a bot at the game, playing with an engine of the server or calling a webhook",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BotRequest"}}}},
+        "responses": {
+          "201": {"description": "The seated bot", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Bot"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/engines": {
+      "get": {
+        "summary": "List the engines which can be seated as bots",
+        "responses": {
+          "200": {"description": "The names of the engines", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "string"}}}}}
+        }
+      }
+    },
+    "/games/{gameId}/bots": {
+      "post": {
+        "summary": "Seat a bot at the game, playing with an engine of the server or calling a webhook",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Session"}],
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BotRequest"}}}},
+        "responses": {
+          "201": {"description": "The seated bot", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Bot"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/positions": {
+      "get": {
+        "summary": "Get a page of the positions of the game replayed from its configuration, the initial position first then one after each ply",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The positions", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GamePage"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/positions": {
+      "get": {
+        "summary": "Get a page of the positions of the game replayed from its configuration, the initial position first then one after each ply",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The positions", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GamePage"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/replay": {
+      "get": {
+        "summary": "Export the replay of the game as an animated GIF or a self-contained HTML page, one frame per ply, up to 1000 plies",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/ReplayFormat"}, {"$ref": "#/components/parameters/Delay"}],
+        "responses": {
+          "200": {"description": "The replay", "content": {"image/gif": {"schema": {"type": "string", "format": "binary"}}, "text/html": {"schema": {"type": "string"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/replay": {
+      "get": {
+        "summary": "Export the replay of the game as an animated GIF or a self-contained HTML page, one frame per ply, up to 1000 plies",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/ReplayFormat"}, {"$ref": "#/components/parameters/Delay"}],
+        "responses": {
+          "200": {"description": "The replay", "content": {"image/gif": {"schema": {"type": "string", "format": "binary"}}, "text/html": {"schema": {"type": "string"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/fork": {
+      "post": {
+        "summary": "Fork the game into a new game starting from its position after the ply, the current one by default, and seat the requester, bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}, {"$ref": "#/components/parameters/Creator"}, {"$ref": "#/components/parameters/Session"}],
+        "responses": {
+          "201": {"description": "The new game and the seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSeat"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/fork": {
+      "post": {
+        "summary": "Fork the game into a new game starting from its position after the ply, the current one by default, and seat the requester, bound to the user when logged in",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}, {"$ref": "#/components/parameters/Creator"}, {"$ref": "#/components/parameters/Session"}],
+        "responses": {
+          "201": {"description": "The new game and the seat", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSeat"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/openings": {
+      "get": {
+        "summary": "Explore the opening book built from the first plies of the finished games, positions being keyed by a hash shared with their mirror image",
+        "parameters": [{"$ref": "#/components/parameters/Opening"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The position of the hash, or the starting positions without hash", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/OpeningPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openings": {
+      "get": {
+        "summary": "Explore the opening book built from the first plies of the finished games, positions being keyed by a hash shared with their mirror image",
+        "parameters": [{"$ref": "#/components/parameters/Opening"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The position of the hash, or the starting positions without hash", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/OpeningPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/games/{gameId}/solution": {
+      "get": {
+        "summary": "Solve the pawn race of two pawns of the game, as it was after the ply if any, once no pawn has fences left on a board up to 9 squares wide",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}],
+        "responses": {
+          "200": {"description": "The outcome under perfect play", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Solution"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/solution": {
+      "get": {
+        "summary": "Solve the pawn race of two pawns of the game, as it was after the ply if any, once no pawn has fences left on a board up to 9 squares wide",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}],
+        "responses": {
+          "200": {"description": "The outcome under perfect play", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Solution"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games": {
+      "get": {
+        "summary": "List the games of the lobby, the games waiting for a player by default",
+        "parameters": [{"$ref": "#/components/parameters/Status"}, {"$ref": "#/components/parameters/BoardSize"}, {"$ref": "#/components/parameters/Page"}, {"$ref": "#/components/parameters/PerPage"}],
+        "responses": {
+          "200": {"description": "The games", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/GameSummaryPage"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Create a game, the default configuration is used without body",
+        "parameters": [{"$ref": "#/components/parameters/Creator"}],
+        "requestBody": {
+          "required": false,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Configuration"}}}
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/Game"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}": {
+      "get": {
+        "summary": "Get the game, or the game as it was after a number of plies, as a text board or an SVG or PNG image drawn with the render options when accepted",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Ply"}, {"$ref": "#/components/parameters/Coordinates"}, {"$ref": "#/components/parameters/LegalMoves"}, {"$ref": "#/components/parameters/LastMove"}, {"$ref": "#/components/parameters/FencesLeft"}, {"$ref": "#/components/parameters/ASCII"}, {"$ref": "#/components/parameters/Color"}],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Game"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/join": {
+      "put": {
+        "summary": "Join the game and get the token of the player",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}],
+        "responses": {
+          "200": {"description": "The player token", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/AuthorizationToken"}}}},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/add-fence": {
+      "put": {
+        "summary": "Add a fence for the player",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Authorization"}],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Fence"}}}
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/Game"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/add-fence/possibilities": {
+      "get": {
+        "summary": "Get the places where the current pawn can add a fence",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}],
+        "responses": {
+          "200": {"description": "The fences", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Fence"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/move-pawn": {
+      "put": {
+        "summary": "Move the pawn of the player",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Authorization"}],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Position"}}}
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/Game"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/move-pawn/possibilities": {
+      "get": {
+        "summary": "Get the squares where the current pawn can move",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}],
+        "responses": {
+          "200": {"description": "The squares", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Position"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/games/{gameId}/actions": {
+      "post": {
+        "summary": "Play a pawn move or a fence addition for the player",
+        "parameters": [{"$ref": "#/components/parameters/GameId"}, {"$ref": "#/components/parameters/Authorization"}],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Action"}}}
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/Game"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "Opening": {"name": "position", "in": "query", "required": false, "description": "The hash of the position of the book, as given by the next position of its moves", "schema": {"type": "string"}},
+      "Ply": {"name": "ply", "in": "query", "required": false, "description": "The number of plies after which the game is replayed, 0 for its initial position", "schema": {"type": "integer", "minimum": 0}},
+      "ReplayFormat": {"name": "format", "in": "query", "required": false, "description": "The format of the replay, negotiated from the Accept header if missing, gif by default", "schema": {"type": "string", "enum": ["gif", "html"]}},
+      "Delay": {"name": "delay", "in": "query", "required": false, "description": "The milliseconds each ply is shown, the final position being shown three times longer", "schema": {"type": "integer", "minimum": 20, "maximum": 10000, "default": 800}},
+      "Coordinates": {"name": "coordinates", "in": "query", "required": false, "description": "Label the columns and the rows of the text board, the images being always labeled", "schema": {"type": "boolean", "default": false}},
+      "LegalMoves": {"name": "legalMoves", "in": "query", "required": false, "description": "Mark the squares the current pawn can move to on the text board", "schema": {"type": "boolean", "default": false}},
+      "LastMove": {"name": "lastMove", "in": "query", "required": false, "description": "Mark the last action on the text board or the image", "schema": {"type": "boolean", "default": false}},
+      "FencesLeft": {"name": "fencesLeft", "in": "query", "required": false, "description": "List the fences left to each pawn and the pawn to play under the text board", "schema": {"type": "boolean", "default": false}},
+      "ASCII": {"name": "ascii", "in": "query", "required": false, "description": "Draw the text board with ASCII characters only", "schema": {"type": "boolean", "default": false}},
+      "Color": {"name": "color", "in": "query", "required": false, "description": "Color the text board with ANSI escapes", "schema": {"type": "boolean", "default": false}},
+      "GameId": {"name": "gameId", "in": "path", "required": true, "schema": {"type": "string"}},
+      "Status": {"name": "status", "in": "query", "required": false, "schema": {"type": "string", "enum": ["waiting", "playing", "over"], "default": "waiting"}},
+      "BoardSize": {"name": "boardSize", "in": "query", "required": false, "schema": {"type": "integer", "minimum": 1}},
+      "Creator": {"name": "creator", "in": "query", "required": false, "description": "The name of the creator shown in the lobby", "schema": {"type": "string"}},
+      "Page": {"name": "page", "in": "query", "required": false, "schema": {"type": "integer", "minimum": 1, "default": 1}},
+      "PerPage": {"name": "perPage", "in": "query", "required": false, "schema": {"type": "integer", "minimum": 1, "maximum": 100, "default": 20}},
+      "UserId": {"name": "userId", "in": "path", "required": true, "schema": {"type": "string"}},
+      "RatedBoardSize": {"name": "boardSize", "in": "query", "required": false, "description": "Each board size is rated apart", "schema": {"type": "integer", "minimum": 1, "default": 9}},
+      "TournamentId": {"name": "tournamentId", "in": "path", "required": true, "schema": {"type": "string"}},
+      "OrganizerToken": {"name": "Authorization", "in": "header", "required": true, "description": "The token given when creating the tournament", "schema": {"type": "string"}},
+      "ParticipantToken": {"name": "Authorization", "in": "header", "required": true, "description": "The token given when registering to the tournament", "schema": {"type": "string"}},
+      "TicketId": {"name": "ticketId", "in": "path", "required": true, "schema": {"type": "string"}},
+      "Wait": {"name": "wait", "in": "query", "required": false, "description": "The seconds to wait for an opponent", "schema": {"type": "integer", "minimum": 1, "maximum": 60, "default": 30}},
+      "Session": {"name": "Authorization", "in": "header", "required": false, "description": "The bearer token of the session of the user", "schema": {"type": "string"}},
+      "RequiredSession": {"name": "Authorization", "in": "header", "required": true, "description": "The bearer token of the session of the user", "schema": {"type": "string"}},
+      "Authorization": {"name": "Authorization", "in": "header", "required": true, "description": "The signed token given when joining the game, with or without the Bearer prefix", "schema": {"type": "string"}}
+    },
+    "responses": {
+      "Game": {
+        "description": "The game",
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/Game"}},
+          "text/plain": {"schema": {"type": "string"}},
+          "image/svg+xml": {"schema": {"type": "string"}},
+          "image/png": {"schema": {"type": "string", "format": "binary"}}
+        }
+      },
+      "GameResource": {
+        "description": "The game",
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/GameResource"}},
+          "text/plain": {"schema": {"type": "string"}},
+          "image/svg+xml": {"schema": {"type": "string"}},
+          "image/png": {"schema": {"type": "string", "format": "binary"}}
+        }
+      },
+      "Error": {
+        "description": "The error",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      }
+    },
+    "schemas": {
+      "Message": {
+        "type": "object",
+        "properties": {"Message": {"type": "string"}}
+      },
+      "AuthorizationToken": {
+        "type": "object",
+        "properties": {"AuthToken": {"type": "string"}}
+      },
+      "Error": {
+        "type": "object",
+        "required": ["code", "message"],
+        "properties": {
+          "code": {"type": "string", "enum": ["bad_request", "invalid_request", "not_found", "forbidden", "not_ready", "not_your_turn", "conflict", "game_over", "illegal_move", "illegal_fence", "no_more_fences", "invalid_action", "invalid_configuration", "unauthorized", "too_large"]},
+          "message": {"type": "string"}
+        }
+      },
+      "MatchmakingPreferences": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string"},
+          "boardSize": {"type": "integer", "minimum": 3, "maximum": 25},
+          "timeControl": {"type": "string", "description": "Only pairs the players with the same time control or none, the games having no clocks yet"}
+        }
+      },
+      "Ticket": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "status": {"type": "string", "enum": ["searching"]}
+        }
+      },
+      "Match": {
+        "type": "object",
+        "properties": {
+          "gameId": {"type": "string"},
+          "number": {"type": "integer"},
+          "token": {"type": "string"}
+        }
+      },
+      "Position": {
+        "type": "object",
+        "required": ["column", "row"],
+        "additionalProperties": false,
+        "properties": {
+          "column": {"type": "integer", "minimum": 0},
+          "row": {"type": "integer", "minimum": 0}
+        }
+      },
+      "Fence": {
+        "type": "object",
+        "required": ["square", "horizontal"],
+        "additionalProperties": false,
+        "properties": {
+          "square": {"$ref": "#/components/schemas/Position"},
+          "horizontal": {"type": "boolean"}
+        }
+      },
+      "Configuration": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {
+          "boardSize": {"type": "integer", "minimum": 3, "maximum": 25},
+          "numberOfFencesPerPlayer": {"type": "integer", "minimum": 0},
+          "blockedSquares": {"type": "array", "items": {"$ref": "#/components/schemas/Position"}},
+          "walls": {"type": "array", "items": {"$ref": "#/components/schemas/Fence"}},
+          "jumpRule": {"type": "integer", "enum": [0, 1, 2], "description": "0 official, 1 edge counts as wall, 2 no diagonal jumps"},
+          "variant": {"type": "string", "enum": ["", "standard", "kids", "move-or-fence-twice"]}
+        }
+      },
+      "Action": {
+        "type": "object",
+        "required": ["type"],
+        "additionalProperties": false,
+        "properties": {
+          "type": {"type": "string", "enum": ["move", "fence"]},
+          "position": {"$ref": "#/components/schemas/Position"},
+          "fence": {"$ref": "#/components/schemas/Fence"}
+        }
+      },
+      "Pawn": {
+        "type": "object",
+        "properties": {
+          "position": {"$ref": "#/components/schemas/Position"},
+          "goal": {"type": "integer", "description": "0 north, 1 east, 2 south, 3 west"},
+          "fencesLeft": {"type": "integer"}
+        }
+      },
+      "Board": {
+        "type": "object",
+        "properties": {
+          "boardSize": {"type": "integer"},
+          "squares": {"type": "array", "items": {"$ref": "#/components/schemas/Position"}},
+          "blockedSquares": {"type": "array", "items": {"$ref": "#/components/schemas/Position"}}
+        }
+      },
+      "SeatToken": {
+        "type": "object",
+        "properties": {
+          "number": {"type": "integer"},
+          "token": {"type": "string"}
+        }
+      },
+      "PlayerResource": {
+        "type": "object",
+        "properties": {
+          "number": {"type": "integer"},
+          "seated": {"type": "boolean"},
+          "position": {"$ref": "#/components/schemas/Position"},
+          "goal": {"type": "string", "enum": ["north", "east", "south", "west"]},
+          "fencesLeft": {"type": "integer"}
+        }
+      },
+      "GameResource": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "status": {"type": "string", "enum": ["waiting", "playing", "over"]},
+          "variant": {"type": "string"},
+          "jumpRule": {"type": "integer"},
+          "boardSize": {"type": "integer"},
+          "blockedSquares": {"type": "array", "items": {"$ref": "#/components/schemas/Position"}},
+          "currentPlayer": {"type": "integer"},
+          "turnActions": {"type": "integer"},
+          "players": {"type": "array", "items": {"$ref": "#/components/schemas/PlayerResource"}},
+          "fences": {"type": "array", "items": {"$ref": "#/components/schemas/Fence"}},
+          "winner": {"type": "integer", "description": "The number of the winner, once the game is over"},
+          "forkedFrom": {"$ref": "#/components/schemas/Fork"}
+        }
+      },
+      "GameSummary": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "status": {"type": "string", "enum": ["waiting", "playing", "over"]},
+          "createdAt": {"type": "string", "format": "date-time"},
+          "creator": {"type": "string"},
+          "configuration": {"$ref": "#/components/schemas/Configuration"},
+          "players": {"type": "integer"},
+          "forkedFrom": {"$ref": "#/components/schemas/Fork"}
+        }
+      },
+      "Fork": {
+        "type": "object",
+        "description": "The game and the ply a game was forked from, the plies of the fork counting from this position",
+        "properties": {
+          "gameId": {"type": "string"},
+          "ply": {"type": "integer"}
+        }
+      },
+      "GameSummaryPage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/GameSummary"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "PlayedGame": {
+        "allOf": [
+          {"$ref": "#/components/schemas/GameSummary"},
+          {"type": "object", "properties": {"number": {"type": "integer"}}}
+        ]
+      },
+      "PlayedGamePage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/PlayedGame"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "Credentials": {
+        "type": "object",
+        "required": ["name", "password"],
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string"},
+          "password": {"type": "string"}
+        }
+      },
+      "User": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "createdAt": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Session": {
+        "type": "object",
+        "properties": {
+          "token": {"type": "string"},
+          "user": {"$ref": "#/components/schemas/User"},
+          "expiresAt": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Rating": {
+        "type": "object",
+        "properties": {
+          "rating": {"type": "number"},
+          "deviation": {"type": "number"},
+          "volatility": {"type": "number"}
+        }
+      },
+      "RatingChange": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Rating"},
+          {"type": "object", "properties": {"gameId": {"type": "string"}, "at": {"type": "string", "format": "date-time"}}}
+        ]
+      },
+      "PlayerRating": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Rating"},
+          {
+            "type": "object",
+            "properties": {
+              "userId": {"type": "string"},
+              "name": {"type": "string"},
+              "boardSize": {"type": "integer"},
+              "games": {"type": "integer"},
+              "history": {"type": "array", "items": {"$ref": "#/components/schemas/RatingChange"}}
+            }
+          }
+        ]
+      },
+      "PlayerRatingPage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/PlayerRating"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "TournamentSettings": {
+        "type": "object",
+        "required": ["name", "format"],
+        "additionalProperties": false,
+        "properties": {
+          "name": {"type": "string"},
+          "format": {"type": "string", "enum": ["round-robin", "swiss"]},
+          "rounds": {"type": "integer", "minimum": 0, "description": "The number of Swiss rounds, the binary logarithm of the number of participants by default"},
+          "configuration": {"$ref": "#/components/schemas/Configuration"},
+          "roundMinutes": {"type": "integer", "minimum": 0, "description": "The time given to play each round, the games still playing being forfeited by the pawn to play, 24 hours by default"}
+        }
+      },
+      "ParticipantName": {
+        "type": "object",
+        "required": ["name"],
+        "additionalProperties": false,
+        "properties": {"name": {"type": "string"}}
+      },
+      "Participant": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "userId": {"type": "string"}
+        }
+      },
+      "Registration": {
+        "type": "object",
+        "properties": {
+          "participant": {"$ref": "#/components/schemas/Participant"},
+          "token": {"type": "string"}
+        }
+      },
+      "Pairing": {
+        "type": "object",
+        "description": "A game between two participants, or a bye without second participant",
+        "properties": {
+          "first": {"type": "string"},
+          "second": {"type": "string"},
+          "gameId": {"type": "string"},
+          "winner": {"type": "string"}
+        }
+      },
+      "Round": {
+        "type": "object",
+        "properties": {
+          "number": {"type": "integer"},
+          "pairings": {"type": "array", "items": {"$ref": "#/components/schemas/Pairing"}},
+          "deadline": {"type": "string", "format": "date-time"}
+        }
+      },
+      "Tournament": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "format": {"type": "string", "enum": ["round-robin", "swiss"]},
+          "status": {"type": "string", "enum": ["registering", "running", "finished"]},
+          "configuration": {"$ref": "#/components/schemas/Configuration"},
+          "numberOfRounds": {"type": "integer"},
+          "participants": {"type": "array", "items": {"$ref": "#/components/schemas/Participant"}},
+          "rounds": {"type": "array", "items": {"$ref": "#/components/schemas/Round"}},
+          "createdAt": {"type": "string", "format": "date-time"},
+          "roundMinutes": {"type": "integer"},
+          "error": {"type": "string", "description": "Why the next round could not be created yet, its creation being tried again"}
+        }
+      },
+      "TournamentCreation": {
+        "allOf": [
+          {"$ref": "#/components/schemas/Tournament"},
+          {"type": "object", "properties": {"organizerToken": {"type": "string", "description": "The token required to start the tournament"}}}
+        ]
+      },
+      "TournamentPage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Tournament"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "Standing": {
+        "type": "object",
+        "properties": {
+          "rank": {"type": "integer"},
+          "participant": {"$ref": "#/components/schemas/Participant"},
+          "points": {"type": "number"},
+          "buchholz": {"type": "number"},
+          "sonnebornBerger": {"type": "number"},
+          "wins": {"type": "integer"},
+          "losses": {"type": "integer"},
+          "byes": {"type": "integer"}
+        }
+      },
+      "GameSeat": {
+        "type": "object",
+        "properties": {
+          "gameId": {"type": "string"},
+          "number": {"type": "integer"},
+          "token": {"type": "string"}
+        }
+      },
+      "BotRequest": {
+        "type": "object",
+        "description": "Either an engine of the server or the url of a webhook bot. On each of its turns, a webhook bot receives a POST of the BotTurn and must answer 200 with the Action to play. It is called again after a network or server error, and resigns once all the calls have failed or when its action is illegal. Only a logged in user can add a webhook bot, whose host must be allowed by the server and resolve to public addresses.",
+        "properties": {
+          "engine": {"type": "string", "example": "greedy"},
+          "url": {"type": "string", "example": "https://bot.example.com/quoridor"}
+        }
+      },
+      "Bot": {
+        "type": "object",
+        "properties": {
+          "engine": {"type": "string"},
+          "url": {"type": "string"},
+          "number": {"type": "integer"}
+        }
+      },
+      "BotTurn": {
+        "type": "object",
+        "properties": {
+          "gameId": {"type": "string"},
+          "number": {"type": "integer"},
+          "moveTime": {"type": "integer", "description": "The time to think, in milliseconds"},
+          "game": {"$ref": "#/components/schemas/Game"},
+          "legalActions": {"type": "array", "items": {"$ref": "#/components/schemas/Action"}}
+        }
+      },
+      "Solution": {
+        "type": "object",
+        "properties": {
+          "winner": {"type": "integer", "description": "The number of the pawn forcing its win, 0 when each pawn can keep the other one from its goal line forever"},
+          "plies": {"type": "integer", "description": "The number of plies before the end, the winner going the shortest way and the loser the longest one"},
+          "action": {"$ref": "#/components/schemas/Action"}
+        }
+      },
+      "MoveStats": {
+        "type": "object",
+        "description": "The outcomes of the games for the player who played the action",
+        "properties": {
+          "action": {"$ref": "#/components/schemas/Action"},
+          "notation": {"type": "string"},
+          "next": {"type": "string", "description": "The hash of the position the action leads to"},
+          "games": {"type": "integer"},
+          "wins": {"type": "integer"},
+          "draws": {"type": "integer"},
+          "losses": {"type": "integer"}
+        }
+      },
+      "Opening": {
+        "type": "object",
+        "properties": {
+          "position": {"type": "string"},
+          "ply": {"type": "integer"},
+          "games": {"type": "integer"},
+          "moves": {"type": "array", "items": {"$ref": "#/components/schemas/MoveStats"}}
+        }
+      },
+      "OpeningPage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Opening"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "ActionPage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Action"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "Game": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "over": {"type": "boolean"},
+          "pawnTurn": {"type": "integer"},
+          "pawn": {"type": "array", "items": {"$ref": "#/components/schemas/Pawn"}},
+          "fences": {"type": "array", "items": {"$ref": "#/components/schemas/Fence"}},
+          "board": {"$ref": "#/components/schemas/Board"},
+          "jumpRule": {"type": "integer"},
+          "variant": {"type": "string"},
+          "turnActions": {"type": "integer"},
+          "resigned": {"type": "integer", "description": "The number of the pawn which has resigned, if any"},
+          "history": {"type": "array", "items": {"$ref": "#/components/schemas/PlayedAction"}}
+        }
+      },
+      "GamePage": {
+        "type": "object",
+        "properties": {
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Game"}},
+          "page": {"type": "integer"},
+          "perPage": {"type": "integer"},
+          "total": {"type": "integer"}
+        }
+      },
+      "PlayedAction": {
+        "type": "object",
+        "properties": {
+          "number": {"type": "integer"},
+          "action": {"$ref": "#/components/schemas/Action"},
+          "from": {"$ref": "#/components/schemas/Position"}
+        }
+      }
+    }
+  }
+}`
+
+// Specification is the decoded document used to validate the requests
+type Specification map[string]interface{}
+
+// Load decode the document
+func Load() (Specification, error) {
+	var specification Specification
+	err := json.Unmarshal([]byte(Document), &specification)
+	return specification, err
+}
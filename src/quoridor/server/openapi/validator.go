@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"quoridor/exception"
+)
+
+const refPrefix = "#/components/"
+
+// ValidateRequest check the headers and the body of the request against the operation of the path template.
+// Operations missing from the specification are not validated.
+func (s Specification) ValidateRequest(pathTemplate string, method string, header http.Header, body []byte) error {
+	operation, found := s.operation(pathTemplate, method)
+	if !found {
+		return nil
+	}
+	if err := s.validateHeaders(operation, header); err != nil {
+		return err
+	}
+	return s.validateBody(operation, body)
+}
+
+func (s Specification) operation(pathTemplate string, method string) (map[string]interface{}, bool) {
+	paths, _ := s["paths"].(map[string]interface{})
+	path, found := paths[pathTemplate].(map[string]interface{})
+	if !found {
+		return nil, false
+	}
+	operation, found := path[strings.ToLower(method)].(map[string]interface{})
+	return operation, found
+}
+
+func (s Specification) validateHeaders(operation map[string]interface{}, header http.Header) error {
+	parameters, _ := operation["parameters"].([]interface{})
+	for _, p := range parameters {
+		parameter := s.resolve(p)
+		if parameter["in"] != "header" || parameter["required"] != true {
+			continue
+		}
+		name, _ := parameter["name"].(string)
+		if header.Get(name) == "" {
+			return invalidRequest("The header %v is required", name)
+		}
+	}
+	return nil
+}
+
+func (s Specification) validateBody(operation map[string]interface{}, body []byte) error {
+	requestBody, found := operation["requestBody"].(map[string]interface{})
+	if !found {
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if requestBody["required"] == true {
+			return invalidRequest("The request body is required")
+		}
+		return nil
+	}
+	content, _ := requestBody["content"].(map[string]interface{})
+	mediaType, _ := content["application/json"].(map[string]interface{})
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return invalidRequest("The request body is not valid JSON: %v", err)
+	}
+	return s.validate(mediaType["schema"], value, "body")
+}
+
+// resolve follow the reference to the components if any
+func (s Specification) resolve(node interface{}) map[string]interface{} {
+	object, _ := node.(map[string]interface{})
+	ref, isRef := object["$ref"].(string)
+	if !isRef || !strings.HasPrefix(ref, refPrefix) {
+		return object
+	}
+	var current interface{} = s["components"]
+	for _, key := range strings.Split(strings.TrimPrefix(ref, refPrefix), "/") {
+		currentObject, _ := current.(map[string]interface{})
+		current = currentObject[key]
+	}
+	return s.resolve(current)
+}
+
+func (s Specification) validate(node interface{}, value interface{}, path string) error {
+	schema := s.resolve(node)
+	if schema == nil {
+		return nil
+	}
+	if enum, found := schema["enum"].([]interface{}); found && !isInEnum(enum, value) {
+		return invalidRequest("%v must be one of %v", path, enum)
+	}
+	switch schema["type"] {
+	case "object":
+		return s.validateObject(schema, value, path)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return invalidRequest("%v must be an array", path)
+		}
+		for index, item := range items {
+			if err := s.validate(schema["items"], item, fmt.Sprintf("%v[%v]", path, index)); err != nil {
+				return err
+			}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return invalidRequest("%v must be an integer", path)
+		}
+		integer, err := number.Float64()
+		if err != nil || integer != math.Trunc(integer) {
+			return invalidRequest("%v must be an integer", path)
+		}
+		if minimum, found := schema["minimum"].(float64); found && integer < minimum {
+			return invalidRequest("%v must be at least %v", path, minimum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalidRequest("%v must be a boolean", path)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return invalidRequest("%v must be a string", path)
+		}
+	}
+	return nil
+}
+
+func (s Specification) validateObject(schema map[string]interface{}, value interface{}, path string) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return invalidRequest("%v must be an object", path)
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if _, found := object[name.(string)]; !found {
+			return invalidRequest("%v.%v is required", path, name)
+		}
+	}
+	for name, property := range object {
+		propertySchema, found := properties[name]
+		if !found {
+			if schema["additionalProperties"] == false {
+				return invalidRequest("%v.%v is not allowed", path, name)
+			}
+			continue
+		}
+		if err := s.validate(propertySchema, property, path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isInEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if number, ok := value.(json.Number); ok {
+			if float, err := number.Float64(); err == nil && float == allowed {
+				return true
+			}
+			continue
+		}
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidRequest(format string, args ...interface{}) error {
+	return exception.New(exception.INVALID_REQUEST, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+
+	"quoridor/controller"
+	"quoridor/openings"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+var recordingOpenings sync.Once
+
+// InitOpenings feed the default book with the games as soon as they end
+func InitOpenings() {
+	recordingOpenings.Do(func() {
+		gamecontroller.OnGameOver(RecordOpening)
+	})
+}
+
+// RecordOpening add the opening of the finished game to the default book, the forks being left out
+// as they do not start from the starting position
+func RecordOpening(result gamecontroller.GameResult) {
+	if forkedFrom, err := gamecontroller.GetForkedFrom(result.GameID); err != nil || forkedFrom != nil {
+		return
+	}
+	positions, err := gamecontroller.GetPositions(result.GameID)
+	if err != nil {
+		return
+	}
+	openings.Default.Add(positions, result.Winner)
+}
+
+func registerOpeningRoutes(router *mux.Router) {
+	router.HandleFunc("/openings", openingsHandler).Methods("GET")
+}
+
+// openingsHandler get the position of the book with the hash of the query, or the starting positions without hash,
+// the next position of each move being the hash to explore next
+func openingsHandler(w http.ResponseWriter, r *http.Request) {
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	found := []openings.Opening{}
+	if hash := request.GetOpening(r); hash == "" {
+		found = openings.Default.Starts()
+	} else if opening, exists := openings.Default.Get(hash); exists {
+		found = append(found, opening)
+	}
+	start, end := pagination.Bounds(len(found))
+	response.SendOK(w, response.Page{found[start:end], pagination.Page, pagination.PerPage, len(found)})
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/game"
+	"quoridor/rating"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+func registerRatingRoutes(router *mux.Router) {
+	router.HandleFunc("/players/{userId}/rating", getRatingHandler).Methods("GET")
+	router.HandleFunc("/leaderboard", leaderboardHandler).Methods("GET")
+}
+
+func getRatingHandler(w http.ResponseWriter, r *http.Request) {
+	boardSize, err := request.GetBoardSize(r, game.DefaultBoardSize)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	playerRating, err := rating.GetRating(request.GetUserID(r), boardSize)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, playerRating)
+}
+
+func leaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	boardSize, err := request.GetBoardSize(r, 0)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	ratings := rating.Leaderboard(boardSize)
+	start, end := pagination.Bounds(len(ratings))
+	response.SendOK(w, response.Page{ratings[start:end], pagination.Page, pagination.PerPage, len(ratings)})
+}
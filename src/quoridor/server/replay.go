@@ -0,0 +1,112 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/boardimage"
+	"quoridor/controller"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+func registerReplayRoutes(router *mux.Router) {
+	router.HandleFunc("/games/{gameId}/positions", positionsHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}/replay", replayHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}/fork", forkGameHandler).Methods("POST")
+}
+
+// forkGameHandler create a new game from the position after the plies of the query, the current one by default,
+// and seat the requester, bound to the logged in user if the request is authenticated
+func forkGameHandler(w http.ResponseWriter, r *http.Request) {
+	ply, found, err := request.GetPly(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	userID, err := getOptionalUserID(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	gameID := request.GetGameID(r)
+	if !found {
+		g, err := gamecontroller.GetGame(gameID)
+		if err != nil {
+			response.SendError(w, err)
+			return
+		}
+		ply = len(g.History)
+	}
+	fork, err := gamecontroller.ForkGame(gameID, ply, request.GetCreator(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seat, err := gamecontroller.TakeSeat(fork.ID, userID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seatToken, err := newSeatToken(fork.ID, seat)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, GameSeat{fork.ID, seatToken})
+}
+
+// positionsHandler get a page of the positions of the game, from its start to its current position
+func positionsHandler(w http.ResponseWriter, r *http.Request) {
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	positions, err := gamecontroller.GetPositions(request.GetGameID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	start, end := pagination.Bounds(len(positions))
+	response.SendOK(w, response.Page{positions[start:end], pagination.Page, pagination.PerPage, len(positions)})
+}
+
+// replayHandler export the game as an animated GIF or a self-contained HTML page, one frame per ply
+func replayHandler(w http.ResponseWriter, r *http.Request) {
+	contentType, err := request.GetReplayType(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	delay, err := request.GetDelay(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	g, err := gamecontroller.GetGame(request.GetGameID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	if err := boardimage.CheckPlies(len(g.History)); err != nil {
+		response.SendError(w, err)
+		return
+	}
+	positions, err := gamecontroller.GetPositions(g.ID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	export := boardimage.GIF
+	if contentType == boardimage.HTMLContentType {
+		export = boardimage.HTML
+	}
+	replay, err := export(positions, delay)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendContentOK(w, contentType, replay)
+}
@@ -2,24 +2,74 @@ package request
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"quoridor/boardimage"
+	"quoridor/bot"
+	"quoridor/controller"
+	"quoridor/exception"
 	"quoridor/game"
+	"quoridor/matchmaking"
+	"quoridor/tournament"
+	"quoridor/users"
 	"github.com/gorilla/mux"
 )
 
+// Pagination defaults
+const (
+	DefaultPerPage = 20
+	MaxPerPage = 100
+)
+
+// BearerPrefix is the scheme of the tokens sent in the Authorization header
+const BearerPrefix = "Bearer "
+
+// Frame delays of the replays, in milliseconds
+const (
+	DefaultDelay = 800
+	MinDelay     = 20
+	MaxDelay     = 10000
+)
+
+// Long poll durations of the matchmaking, in seconds
+const (
+	DefaultWait = 30
+	MaxWait = 60
+)
+
+// Pagination is the page requested for a listing, starting from 1
+type Pagination struct {
+	Page int
+	PerPage int
+}
+
+// Bounds get the indexes of the page items in a listing of total items
+func (p Pagination) Bounds(total int) (int, int) {
+	start := (p.Page - 1) * p.PerPage
+	if start > total {
+		start = total
+	}
+	end := start + p.PerPage
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
 func GetGameID(r *http.Request) string {
 	vars := mux.Vars(r)
 	return vars["gameId"]
 }
 
 func GetGameConfiguration(r *http.Request) (game.Configuration, error) {
-	decoder := json.NewDecoder(r.Body)
-	defer r.Body.Close()
 	var conf game.Configuration
-	err := decoder.Decode(&conf)
+	err := decode(r, &conf)
 	if err == io.EOF {
-		conf = game.Configuration{9, 10}
+		conf = game.Configuration{BoardSize: game.DefaultBoardSize, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences}
 	} else if err != nil {
 		return game.Configuration{}, err
 	}
@@ -27,10 +77,8 @@ func GetGameConfiguration(r *http.Request) (game.Configuration, error) {
 }
 
 func GetFence(r *http.Request) (game.Fence, error) {
-	decoder := json.NewDecoder(r.Body)
-	defer r.Body.Close()
 	var fence game.Fence
-	err := decoder.Decode(&fence)
+	err := decode(r, &fence)
 	if err != nil {
 		return game.Fence{}, err
 	}
@@ -38,12 +86,256 @@ func GetFence(r *http.Request) (game.Fence, error) {
 }
 
 func GetPosition(r *http.Request) (game.Position, error) {
-	decoder := json.NewDecoder(r.Body)
-	defer r.Body.Close()
 	var position game.Position
-	err := decoder.Decode(&position)
+	err := decode(r, &position)
 	if err != nil {
 		return game.Position{}, err
 	}
 	return position, nil
 }
+
+func GetAction(r *http.Request) (game.Action, error) {
+	var action game.Action
+	err := decode(r, &action)
+	if err != nil {
+		return game.Action{}, err
+	}
+	return action, action.Validate()
+}
+
+// decode the JSON body strictly, unknown fields are rejected
+func decode(r *http.Request, value interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(value)
+}
+
+func GetPagination(r *http.Request) (Pagination, error) {
+	page, err := getPositiveQueryParameter(r, "page", 1)
+	if err != nil {
+		return Pagination{}, err
+	}
+	perPage, err := getPositiveQueryParameter(r, "perPage", DefaultPerPage)
+	if err != nil {
+		return Pagination{}, err
+	}
+	if perPage > MaxPerPage {
+		return Pagination{}, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("perPage must be at most %v", MaxPerPage))
+	}
+	return Pagination{page, perPage}, nil
+}
+
+func getPositiveQueryParameter(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		return 0, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("%v must be a positive integer", name))
+	}
+	return number, nil
+}
+
+func getBooleanQueryParameter(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("%v must be true or false", name))
+	}
+	return flag, nil
+}
+
+// GetAcceptedType get the first media type of the Accept header which is offered, none if no one is offered
+func GetAcceptedType(r *http.Request, offered ...string) string {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accepted, ";", 2)[0])
+		for _, offer := range offered {
+			if strings.EqualFold(mediaType, offer) {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+// GetRenderOptions get the options of the text board, none by default
+func GetRenderOptions(r *http.Request) (game.RenderOptions, error) {
+	var options game.RenderOptions
+	for name, option := range map[string]*bool{
+		"coordinates": &options.Coordinates,
+		"legalMoves":  &options.LegalMoves,
+		"lastMove":    &options.LastMove,
+		"fencesLeft":  &options.FencesLeft,
+		"ascii":       &options.ASCII,
+		"color":       &options.Color,
+	} {
+		flag, err := getBooleanQueryParameter(r, name)
+		if err != nil {
+			return game.RenderOptions{}, err
+		}
+		*option = flag
+	}
+	return options, nil
+}
+
+// GetCreator get the name of the player creating the game, if any
+func GetCreator(r *http.Request) string {
+	return r.URL.Query().Get("creator")
+}
+
+// GetOpening get the hash of the position of the opening book to explore, empty for the starting positions
+func GetOpening(r *http.Request) string {
+	return r.URL.Query().Get("position")
+}
+
+// GetGameFilter get the lobby filter, the games waiting for a player by default
+func GetGameFilter(r *http.Request) (gamecontroller.GameFilter, error) {
+	query := r.URL.Query()
+	status := gamecontroller.Status(query.Get("status"))
+	switch status {
+	case "":
+		status = gamecontroller.WAITING
+	case gamecontroller.WAITING, gamecontroller.PLAYING, gamecontroller.OVER:
+	default:
+		return gamecontroller.GameFilter{}, exception.New(exception.INVALID_REQUEST, "status must be one of waiting, playing or over")
+	}
+	boardSize, err := GetBoardSize(r, 0)
+	if err != nil {
+		return gamecontroller.GameFilter{}, err
+	}
+	return gamecontroller.GameFilter{status, boardSize}, nil
+}
+
+// GetBoardSize get the board size filter of the query
+func GetBoardSize(r *http.Request, defaultValue int) (int, error) {
+	return getPositiveQueryParameter(r, "boardSize", defaultValue)
+}
+
+func GetUserID(r *http.Request) string {
+	return mux.Vars(r)["userId"]
+}
+
+func GetTicketID(r *http.Request) string {
+	return mux.Vars(r)["ticketId"]
+}
+
+// GetMatchmakingPreferences get the preferences of the player entering the queue, none by default
+func GetMatchmakingPreferences(r *http.Request) (matchmaking.Preferences, error) {
+	var preferences matchmaking.Preferences
+	err := decode(r, &preferences)
+	if err == io.EOF {
+		return matchmaking.Preferences{}, nil
+	}
+	return preferences, err
+}
+
+// GetWait get how long to wait for a match before answering
+func GetWait(r *http.Request) (time.Duration, error) {
+	seconds, err := getPositiveQueryParameter(r, "wait", DefaultWait)
+	if err != nil {
+		return 0, err
+	}
+	if seconds > MaxWait {
+		return 0, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("wait must be at most %v", MaxWait))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func GetCredentials(r *http.Request) (users.Credentials, error) {
+	var credentials users.Credentials
+	err := decode(r, &credentials)
+	if err != nil {
+		return users.Credentials{}, err
+	}
+	return credentials, nil
+}
+
+// GetBearerToken get the token of the Authorization header, with or without the Bearer prefix
+func GetBearerToken(r *http.Request) string {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) >= len(BearerPrefix) && strings.EqualFold(authorization[:len(BearerPrefix)], BearerPrefix) {
+		return strings.TrimSpace(authorization[len(BearerPrefix):])
+	}
+	return authorization
+}
+
+func GetTournamentID(r *http.Request) string {
+	return mux.Vars(r)["tournamentId"]
+}
+
+func GetTournamentSettings(r *http.Request) (tournament.Settings, error) {
+	var settings tournament.Settings
+	err := decode(r, &settings)
+	if err != nil {
+		return tournament.Settings{}, err
+	}
+	return settings, nil
+}
+
+// GetParticipantName get the name of the participant registering to a tournament
+func GetParticipantName(r *http.Request) (string, error) {
+	var participant struct {
+		Name string `json:"name"`
+	}
+	err := decode(r, &participant)
+	if err != nil {
+		return "", err
+	}
+	return participant.Name, nil
+}
+
+// GetBotRequest get the bot to seat at the game
+func GetBotRequest(r *http.Request) (bot.Request, error) {
+	var botRequest bot.Request
+	err := decode(r, &botRequest)
+	if err != nil {
+		return bot.Request{}, err
+	}
+	return botRequest, nil
+}
+
+// GetDelay get how long each position of a replay is shown
+func GetDelay(r *http.Request) (time.Duration, error) {
+	milliseconds, err := getPositiveQueryParameter(r, "delay", DefaultDelay)
+	if err != nil {
+		return 0, err
+	}
+	if milliseconds < MinDelay || milliseconds > MaxDelay {
+		return 0, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("delay must be between %v and %v", MinDelay, MaxDelay))
+	}
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+// GetReplayType get the media type of the replay, from the format of the query or else from the Accept header
+func GetReplayType(r *http.Request) (string, error) {
+	switch r.URL.Query().Get("format") {
+	case "gif":
+		return boardimage.GIFContentType, nil
+	case "html":
+		return boardimage.HTMLContentType, nil
+	case "":
+		if accepted := GetAcceptedType(r, boardimage.GIFContentType, boardimage.HTMLContentType); accepted != "" {
+			return accepted, nil
+		}
+		return boardimage.GIFContentType, nil
+	}
+	return "", exception.New(exception.INVALID_REQUEST, "format must be one of gif or html")
+}
+
+// GetPly get the number of plies after which the game is requested, if any
+func GetPly(r *http.Request) (int, bool, error) {
+	value := r.URL.Query().Get("ply")
+	if value == "" {
+		return 0, false, nil
+	}
+	ply, err := strconv.Atoi(value)
+	if err != nil || ply < 0 {
+		return 0, false, exception.New(exception.INVALID_REQUEST, "ply must be a positive integer or zero")
+	}
+	return ply, true, nil
+}
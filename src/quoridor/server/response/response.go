@@ -3,8 +3,36 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+
+	"quoridor/exception"
 )
 
+// BadRequestCode is the error code of a request which cannot be understood
+const BadRequestCode = "bad_request"
+
+// Error is the body sent back when a request fails
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+var statuses = map[exception.ErrorType]int{
+	exception.NOT_FOUND:             http.StatusNotFound,
+	exception.FORBIDDEN:             http.StatusForbidden,
+	exception.NOT_READY:             http.StatusConflict,
+	exception.NOT_YOUR_TURN:         http.StatusConflict,
+	exception.CONFLICT:              http.StatusConflict,
+	exception.GAME_OVER:             http.StatusConflict,
+	exception.ILLEGAL_MOVE:          http.StatusUnprocessableEntity,
+	exception.ILLEGAL_FENCE:         http.StatusUnprocessableEntity,
+	exception.NO_MORE_FENCES:        http.StatusUnprocessableEntity,
+	exception.INVALID_ACTION:        http.StatusUnprocessableEntity,
+	exception.INVALID_CONFIGURATION: http.StatusUnprocessableEntity,
+	exception.INVALID_REQUEST:       http.StatusBadRequest,
+	exception.UNAUTHORIZED:          http.StatusUnauthorized,
+	exception.TOO_LARGE:             http.StatusRequestEntityTooLarge,
+}
+
 func SendOK(w http.ResponseWriter, response interface{}) {
 	encodedResponse, err := json.Marshal(response)
 	if err != nil {
@@ -14,15 +42,74 @@ func SendOK(w http.ResponseWriter, response interface{}) {
 	w.Write([]byte(string(encodedResponse)))
 }
 
+// Page is a slice of a listing
+type Page struct {
+	Items   interface{} `json:"items"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"perPage"`
+	Total   int         `json:"total"`
+}
+
+func SendCreated(w http.ResponseWriter, response interface{}) {
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(encodedResponse)
+}
+
+func SendAccepted(w http.ResponseWriter, response interface{}) {
+	encodedResponse, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(encodedResponse)
+}
+
+func SendNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendContentOK send the body as is with its media type
+func SendContentOK(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("content-type", contentType)
+	w.Write(body)
+}
+
 func SendPlainOK(w http.ResponseWriter, message string) {
 	w.Header().Set("content-type", "text/plain")
 	w.Write([]byte(message))
 }
 
+// SendError send the error with the status matching its type, a bad request otherwise
+func SendError(w http.ResponseWriter, err error) {
+	if gameError, ok := err.(*exception.GameError); ok {
+		if status, found := statuses[gameError.ErrorType]; found {
+			send(w, status, Error{gameError.ErrorType.Code(), gameError.Message})
+			return
+		}
+	}
+	SendBadRequestError(w, err)
+}
+
 func SendBadRequestError(w http.ResponseWriter, err error) {
 	SendBadRequest(w, err.Error())
 }
 
 func SendBadRequest(w http.ResponseWriter, message string) {
-	http.Error(w, "{ \"message\": \""+message+"\"}", http.StatusBadRequest)
+	send(w, http.StatusBadRequest, Error{BadRequestCode, message})
+}
+
+func send(w http.ResponseWriter, status int, body Error) {
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	w.Write(encodedBody)
 }
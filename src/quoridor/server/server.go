@@ -1,26 +1,36 @@
 package server
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"strconv"
 
+	"quoridor/boardimage"
 	"quoridor/controller"
+	"quoridor/exception"
 	"quoridor/game"
+	"quoridor/server/openapi"
 	"quoridor/server/request"
 	"quoridor/server/response"
 
 	"github.com/gorilla/mux"
-	"github.com/lithammer/shortuuid"
 )
 
+// TextContentType is the media type of the text boards
+const TextContentType = "text/plain"
+
 // Port is the default server port
 const (
 	Port = 8383
 	AuthorizationHeaderName = "Authorization"
 )
 
+// MaxBodySize is the number of bytes of a request body read at most, a larger body being rejected
+const MaxBodySize = 64 << 10
+
 type Message struct {
 	Message string
 }
@@ -31,18 +41,82 @@ type AuthorizationToken struct {
 
 // Start launch the server
 func Start() {
+	router, err := NewRouter()
+	if err != nil {
+		log.Fatal(err)
+	}
+	port := getListeningPort()
+	fmt.Printf("Server started on port: %v\n", port)
+	log.Fatal(http.ListenAndServe(":"+port, router))
+}
+
+// NewRouter create the router of the API, validating the requests against the OpenAPI document
+func NewRouter() (*mux.Router, error) {
+	specification, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
 	router := mux.NewRouter().StrictSlash(true)
+	router.Use(validationMiddleware(specification))
 	router.HandleFunc("/", welcomeHandler).Methods("GET")
+	router.HandleFunc("/openapi.json", openAPIHandler).Methods("GET")
+	v1 := router.PathPrefix(V1Prefix).Subrouter()
+	registerV1Routes(v1)
+	registerUserRoutes(v1)
+	registerMatchmakingRoutes(v1)
+	registerMatchmakingRoutes(router)
+	registerRatingRoutes(v1)
+	registerRatingRoutes(router)
+	registerTournamentRoutes(v1)
+	registerTournamentRoutes(router)
+	registerBotRoutes(v1)
+	registerBotRoutes(router)
+	registerReplayRoutes(v1)
+	registerReplayRoutes(router)
+	registerOpeningRoutes(v1)
+	registerOpeningRoutes(router)
+	registerAnalysisRoutes(v1)
+	registerAnalysisRoutes(router)
+	// Legacy routes, kept for the clients written before the version 1
 	router.HandleFunc("/games", CreateGameHandler).Methods("POST")
+	router.HandleFunc("/games", lobbyHandler).Methods("GET")
 	router.HandleFunc("/games/{gameId}", getGameHandler).Methods("GET")
 	router.HandleFunc("/games/{gameId}/join", joinGameHandler).Methods("PUT")
-	router.HandleFunc("/games/{gameId}/add-fence", addFenceHandler).Methods("PUT")
+	router.HandleFunc("/games/{gameId}/add-fence", authenticatePlayer(addFenceHandler)).Methods("PUT")
 	router.HandleFunc("/games/{gameId}/add-fence/possibilities", getFencePossibilitiesHandler).Methods("GET")
-	router.HandleFunc("/games/{gameId}/move-pawn", movePawnHandler).Methods("PUT")
+	router.HandleFunc("/games/{gameId}/move-pawn", authenticatePlayer(movePawnHandler)).Methods("PUT")
 	router.HandleFunc("/games/{gameId}/move-pawn/possibilities", getMovePossibilitiesHandler).Methods("GET")
-	port := getListeningPort()
-	fmt.Printf("Server started on port: %v\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, router))
+	router.HandleFunc("/games/{gameId}/actions", authenticatePlayer(playActionHandler)).Methods("POST")
+	return router, nil
+}
+
+func validationMiddleware(specification openapi.Specification) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pathTemplate, err := mux.CurrentRoute(r).GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+			if err != nil && len(body) >= MaxBodySize {
+				response.SendError(w, exception.New(exception.TOO_LARGE, fmt.Sprintf("The body must be at most %v bytes", MaxBodySize)))
+				return
+			}
+			if err != nil {
+				response.SendError(w, err)
+				return
+			}
+			r.Body.Close()
+			err = specification.ValidateRequest(pathTemplate, r.Method, r.Header, body)
+			if err != nil {
+				response.SendError(w, err)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 func getListeningPort() string {
@@ -53,25 +127,57 @@ func welcomeHandler(w http.ResponseWriter, r *http.Request) {
 	response.SendOK(w, Message{"Welcome to the Quoridor API!"})
 }
 
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("content-type", "application/json")
+	w.Write([]byte(openapi.Document))
+}
+
 func CreateGameHandler(w http.ResponseWriter, r *http.Request) {
 	configuration, err := request.GetGameConfiguration(r)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
-	game, err := gamecontroller.CreateGame(configuration)
+	game, err := gamecontroller.HostGame(configuration, request.GetCreator(r))
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	sendGameRepresentation(w, r, *game)
 }
 
+func lobbyHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := request.GetGameFilter(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	games := gamecontroller.ListGames(filter)
+	start, end := pagination.Bounds(len(games))
+	response.SendOK(w, response.Page{games[start:end], pagination.Page, pagination.PerPage, len(games)})
+}
+
+// getRequestedGame get the game, as it was after the plies of the query if any
+func getRequestedGame(r *http.Request) (game.Game, error) {
+	ply, found, err := request.GetPly(r)
+	if err != nil {
+		return game.Game{}, err
+	}
+	if found {
+		return gamecontroller.GetGameAt(request.GetGameID(r), ply)
+	}
+	return gamecontroller.GetGame(request.GetGameID(r))
+}
+
 func getGameHandler(w http.ResponseWriter, r *http.Request) {
-	id := request.GetGameID(r)
-	game, err := gamecontroller.GetGame(id)
+	game, err := getRequestedGame(r)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	sendGameRepresentation(w, r, game)
@@ -79,26 +185,29 @@ func getGameHandler(w http.ResponseWriter, r *http.Request) {
 
 func joinGameHandler(w http.ResponseWriter, r *http.Request) {
 	id := request.GetGameID(r)
-	authToken:= shortuuid.New()
-	err := gamecontroller.JoinGame(id, authToken)
+	seat, err := gamecontroller.TakeSeat(id, "")
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
-	response.SendOK(w, AuthorizationToken{authToken})
+	seatToken, err := newSeatToken(id, seat)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, AuthorizationToken{seatToken.Token})
 }
 
 func addFenceHandler(w http.ResponseWriter, r *http.Request) {
 	id := request.GetGameID(r)
 	fence, err := request.GetFence(r)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
-	authToken := r.Header.Get(AuthorizationHeaderName)
-	game, err := gamecontroller.AddFence(id, fence, authToken)
+	game, err := gamecontroller.PlayActionAs(id, game.NewFenceAction(fence), getPlayerNumber(r))
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	sendGameRepresentation(w, r, game)
@@ -108,7 +217,7 @@ func getFencePossibilitiesHandler(w http.ResponseWriter, r *http.Request) {
 	id := request.GetGameID(r)
 	possibilities, err := gamecontroller.GetFencePossibilities(id)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	response.SendOK(w, possibilities)
@@ -118,13 +227,12 @@ func movePawnHandler(w http.ResponseWriter, r *http.Request) {
 	id := request.GetGameID(r)
 	to, err := request.GetPosition(r)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
-	authToken := r.Header.Get(AuthorizationHeaderName)
-	game, err := gamecontroller.MovePawn(id, to, authToken)
+	game, err := gamecontroller.PlayActionAs(id, game.NewMoveAction(to), getPlayerNumber(r))
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	sendGameRepresentation(w, r, game)
@@ -134,16 +242,57 @@ func getMovePossibilitiesHandler(w http.ResponseWriter, r *http.Request) {
 	id := request.GetGameID(r)
 	possibilities, err := gamecontroller.GetMovePossibilities(id)
 	if err != nil {
-		response.SendBadRequestError(w, err)
+		response.SendError(w, err)
 		return
 	}
 	response.SendOK(w, possibilities)
 }
 
+func playActionHandler(w http.ResponseWriter, r *http.Request) {
+	id := request.GetGameID(r)
+	action, err := request.GetAction(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	game, err := gamecontroller.PlayActionAs(id, action, getPlayerNumber(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	sendGameRepresentation(w, r, game)
+}
+
+// sendBoard send the board drawn with the options of the query when the request accepts text or an image,
+// and tell whether it was sent
+func sendBoard(w http.ResponseWriter, r *http.Request, g game.Game) bool {
+	accepted := request.GetAcceptedType(r, TextContentType, boardimage.SVGContentType, boardimage.PNGContentType)
+	if accepted == "" {
+		return false
+	}
+	options, err := request.GetRenderOptions(r)
+	if err != nil {
+		response.SendError(w, err)
+		return true
+	}
+	switch accepted {
+	case TextContentType:
+		response.SendPlainOK(w, g.Render(options))
+	case boardimage.SVGContentType:
+		response.SendContentOK(w, accepted, boardimage.SVG(g, boardimage.Options{options.LastMove}))
+	case boardimage.PNGContentType:
+		image, err := boardimage.PNG(g, boardimage.Options{options.LastMove})
+		if err != nil {
+			response.SendError(w, err)
+			return true
+		}
+		response.SendContentOK(w, accepted, image)
+	}
+	return true
+}
+
 func sendGameRepresentation(w http.ResponseWriter, r *http.Request, game game.Game) {
-	accept := r.Header.Get("Accept")
-	if accept == "text/plain" {
-		response.SendPlainOK(w, game.GetTextBoard())
+	if sendBoard(w, r, game) {
 		return
 	}
 	response.SendOK(w, game)
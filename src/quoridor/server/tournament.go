@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/controller"
+	"quoridor/server/request"
+	"quoridor/server/response"
+	"quoridor/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+// GameSeat is the seat of a participant in its tournament game with the token to play
+type GameSeat struct {
+	GameID string `json:"gameId"`
+	SeatToken
+}
+
+func registerTournamentRoutes(router *mux.Router) {
+	router.HandleFunc("/tournaments", createTournamentHandler).Methods("POST")
+	router.HandleFunc("/tournaments", listTournamentsHandler).Methods("GET")
+	router.HandleFunc("/tournaments/{tournamentId}", getTournamentHandler).Methods("GET")
+	router.HandleFunc("/tournaments/{tournamentId}/participants", registerParticipantHandler).Methods("POST")
+	router.HandleFunc("/tournaments/{tournamentId}/start", startTournamentHandler).Methods("POST")
+	router.HandleFunc("/tournaments/{tournamentId}/standings", getStandingsHandler).Methods("GET")
+	router.HandleFunc("/tournaments/{tournamentId}/seat", getTournamentSeatHandler).Methods("GET")
+}
+
+func createTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := request.GetTournamentSettings(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	t, err := tournament.Create(settings)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, t)
+}
+
+func listTournamentsHandler(w http.ResponseWriter, r *http.Request) {
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	tournaments := tournament.List()
+	start, end := pagination.Bounds(len(tournaments))
+	response.SendOK(w, response.Page{tournaments[start:end], pagination.Page, pagination.PerPage, len(tournaments)})
+}
+
+func getTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	t, err := tournament.Get(request.GetTournamentID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, t)
+}
+
+// registerParticipantHandler register a participant, bound to the logged in user if the request is authenticated
+func registerParticipantHandler(w http.ResponseWriter, r *http.Request) {
+	name, err := request.GetParticipantName(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	userID, err := getOptionalUserID(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	registration, err := tournament.Register(request.GetTournamentID(r), name, userID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, registration)
+}
+
+func startTournamentHandler(w http.ResponseWriter, r *http.Request) {
+	t, err := tournament.Start(request.GetTournamentID(r), request.GetBearerToken(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, t)
+}
+
+func getStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	standings, err := tournament.GetStandings(request.GetTournamentID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, standings)
+}
+
+// getTournamentSeatHandler get the game to play of the participant owning the token of the Authorization header
+func getTournamentSeatHandler(w http.ResponseWriter, r *http.Request) {
+	gameID, number, err := tournament.GetSeat(request.GetTournamentID(r), request.GetBearerToken(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seatToken, err := newSeatToken(gameID, gamecontroller.Seat{number})
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, GameSeat{gameID, seatToken})
+}
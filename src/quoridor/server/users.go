@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/controller"
+	"quoridor/server/request"
+	"quoridor/server/response"
+	"quoridor/users"
+
+	"github.com/gorilla/mux"
+)
+
+func registerUserRoutes(router *mux.Router) {
+	router.HandleFunc("/users", registerHandler).Methods("POST")
+	router.HandleFunc("/users/me", getCurrentUserHandler).Methods("GET")
+	router.HandleFunc("/users/me/games", getCurrentUserGamesHandler).Methods("GET")
+	router.HandleFunc("/sessions", loginHandler).Methods("POST")
+	router.HandleFunc("/sessions", logoutHandler).Methods("DELETE")
+	router.HandleFunc("/games/{gameId}/resume", resumeGameHandler).Methods("POST")
+}
+
+// getCurrentUser get the user logged in with the bearer token of the request
+func getCurrentUser(r *http.Request) (users.User, error) {
+	return users.Authenticate(request.GetBearerToken(r))
+}
+
+// getOptionalUserID get the identifier of the logged in user, empty for an anonymous request
+func getOptionalUserID(r *http.Request) (string, error) {
+	if request.GetBearerToken(r) == "" {
+		return "", nil
+	}
+	user, err := getCurrentUser(r)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func registerHandler(w http.ResponseWriter, r *http.Request) {
+	credentials, err := request.GetCredentials(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	user, err := users.Register(credentials)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, user)
+}
+
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	credentials, err := request.GetCredentials(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	session, err := users.Login(credentials)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, session)
+}
+
+func logoutHandler(w http.ResponseWriter, r *http.Request) {
+	err := users.Logout(request.GetBearerToken(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendNoContent(w)
+}
+
+func getCurrentUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := getCurrentUser(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, user)
+}
+
+func getCurrentUserGamesHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := getCurrentUser(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	games := gamecontroller.ListUserGames(user.ID)
+	start, end := pagination.Bounds(len(games))
+	response.SendOK(w, response.Page{games[start:end], pagination.Page, pagination.PerPage, len(games)})
+}
+
+func resumeGameHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := getCurrentUser(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	id := request.GetGameID(r)
+	seat, err := gamecontroller.ResumeGame(id, user.ID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seatToken, err := newSeatToken(id, seat)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, seatToken)
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"net/http"
+
+	"quoridor/controller"
+	"quoridor/game"
+	"quoridor/server/request"
+	"quoridor/server/response"
+
+	"github.com/gorilla/mux"
+)
+
+// V1Prefix is the path prefix of the version 1 of the API
+const V1Prefix = "/v1"
+
+var goalNames = map[game.Direction]string{
+	game.NORTH: "north",
+	game.EAST:  "east",
+	game.SOUTH: "south",
+	game.WEST:  "west",
+}
+
+// GameResource is the representation of a game in the version 1 of the API
+type GameResource struct {
+	ID             string                `json:"id"`
+	Status         gamecontroller.Status `json:"status"`
+	Variant        string                `json:"variant"`
+	JumpRule       game.JumpRule         `json:"jumpRule"`
+	BoardSize      int                   `json:"boardSize"`
+	BlockedSquares []game.Position       `json:"blockedSquares"`
+	CurrentPlayer  int                   `json:"currentPlayer"`
+	TurnActions    int                   `json:"turnActions"`
+	Players        []PlayerResource      `json:"players"`
+	Fences         []game.Fence          `json:"fences"`
+	Winner         int                   `json:"winner,omitempty"`
+	ForkedFrom     *gamecontroller.Fork  `json:"forkedFrom,omitempty"`
+}
+
+// PlayerResource is the pawn of a player and whether the seat is taken
+type PlayerResource struct {
+	Number     int           `json:"number"`
+	Seated     bool          `json:"seated"`
+	Position   game.Position `json:"position"`
+	Goal       string        `json:"goal"`
+	FencesLeft int           `json:"fencesLeft"`
+}
+
+// SeatToken is the seat given to a player joining a game with the token to play
+type SeatToken struct {
+	Number int    `json:"number"`
+	Token  string `json:"token"`
+}
+
+func registerV1Routes(router *mux.Router) {
+	router.HandleFunc("/games", v1CreateGameHandler).Methods("POST")
+	router.HandleFunc("/games", lobbyHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}", v1GetGameHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}/players", v1GetPlayersHandler).Methods("GET")
+	router.HandleFunc("/games/{gameId}/players", v1JoinGameHandler).Methods("POST")
+	router.HandleFunc("/games/{gameId}/actions", authenticatePlayer(v1PlayActionHandler)).Methods("POST")
+	router.HandleFunc("/games/{gameId}/legal-actions", v1GetLegalActionsHandler).Methods("GET")
+}
+
+func newGameResource(g game.Game) (GameResource, error) {
+	status, err := gamecontroller.GetStatus(g.ID)
+	if err != nil {
+		return GameResource{}, err
+	}
+	if status == gamecontroller.OVER && !g.Over {
+		status = gamecontroller.PLAYING
+	}
+	seats, err := gamecontroller.GetSeats(g.ID)
+	if err != nil {
+		return GameResource{}, err
+	}
+	forkedFrom, err := gamecontroller.GetForkedFrom(g.ID)
+	if err != nil {
+		return GameResource{}, err
+	}
+	players := []PlayerResource{}
+	for index, pawn := range g.Pawns {
+		seated := index < len(seats)
+		players = append(players, PlayerResource{index + 1, seated, pawn.Position, goalNames[pawn.Goal], pawn.FencesLeft})
+	}
+	winner := 0
+	if g.Over {
+		winner = g.Winner()
+	}
+	return GameResource{
+		g.ID,
+		status,
+		g.Variant,
+		g.JumpRule,
+		g.Board.BoardSize,
+		g.Board.BlockedSquares,
+		g.PawnTurn,
+		g.TurnActions,
+		players,
+		g.Fences,
+		winner,
+		forkedFrom,
+	}, nil
+}
+
+func sendGameResource(w http.ResponseWriter, r *http.Request, g game.Game, send func(http.ResponseWriter, interface{})) {
+	if sendBoard(w, r, g) {
+		return
+	}
+	resource, err := newGameResource(g)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	send(w, resource)
+}
+
+func v1CreateGameHandler(w http.ResponseWriter, r *http.Request) {
+	configuration, err := request.GetGameConfiguration(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	g, err := gamecontroller.HostGame(configuration, request.GetCreator(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	sendGameResource(w, r, *g, response.SendCreated)
+}
+
+func v1GetGameHandler(w http.ResponseWriter, r *http.Request) {
+	g, err := getRequestedGame(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	sendGameResource(w, r, g, response.SendOK)
+}
+
+func v1GetPlayersHandler(w http.ResponseWriter, r *http.Request) {
+	g, err := gamecontroller.GetGame(request.GetGameID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	resource, err := newGameResource(g)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendOK(w, resource.Players)
+}
+
+// v1JoinGameHandler seat a new player, bound to the logged in user if the request is authenticated
+func v1JoinGameHandler(w http.ResponseWriter, r *http.Request) {
+	id := request.GetGameID(r)
+	userID, err := getOptionalUserID(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seat, err := gamecontroller.TakeSeat(id, userID)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	seatToken, err := newSeatToken(id, seat)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	response.SendCreated(w, seatToken)
+}
+
+func v1PlayActionHandler(w http.ResponseWriter, r *http.Request) {
+	action, err := request.GetAction(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	g, err := gamecontroller.PlayActionAs(request.GetGameID(r), action, getPlayerNumber(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	sendGameResource(w, r, g, response.SendOK)
+}
+
+func v1GetLegalActionsHandler(w http.ResponseWriter, r *http.Request) {
+	pagination, err := request.GetPagination(r)
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	actions, err := gamecontroller.GetLegalActions(request.GetGameID(r))
+	if err != nil {
+		response.SendError(w, err)
+		return
+	}
+	start, end := pagination.Bounds(len(actions))
+	response.SendOK(w, response.Page{actions[start:end], pagination.Page, pagination.PerPage, len(actions)})
+}
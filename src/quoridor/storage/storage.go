@@ -17,3 +17,16 @@ func Set(id string, value interface{}) {
 func Get(id string) (interface{}, bool) {
 	return c.Get(id)
 }
+
+// List get all the stored values
+func List() []interface{} {
+	values := []interface{}{}
+	for _, item := range c.Items() {
+		values = append(values, item.Object)
+	}
+	return values
+}
+
+func Delete(id string) {
+	c.Delete(id)
+}
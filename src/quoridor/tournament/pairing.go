@@ -0,0 +1,123 @@
+package tournament
+
+// roundRobinSchedule pair every participant with every other with the circle method,
+// a participant getting a bye each round when they are odd
+func roundRobinSchedule(participants []Participant) [][]Pairing {
+	ids := []string{}
+	for _, participant := range participants {
+		ids = append(ids, participant.ID)
+	}
+	if len(ids)%2 == 1 {
+		ids = append(ids, "")
+	}
+	n := len(ids)
+	rounds := [][]Pairing{}
+	for round := 0; round < n-1; round++ {
+		pairings := []Pairing{}
+		for i := 0; i < n/2; i++ {
+			first, second := ids[i], ids[n-1-i]
+			// alternate who starts the game of the fixed participant
+			if i == 0 && round%2 == 1 {
+				first, second = second, first
+			}
+			if first == "" {
+				first, second = second, first
+			}
+			pairings = append(pairings, Pairing{First: first, Second: second})
+		}
+		rounds = append(rounds, pairings)
+		// rotate every participant but the first one
+		ids = append([]string{ids[0], ids[n-1]}, ids[1:n-1]...)
+	}
+	return rounds
+}
+
+// swissPairings pair the participants of close standings who have not met yet,
+// the lowest ranked participant without a bye getting one when they are odd
+func swissPairings(t Tournament) []Pairing {
+	ids := []string{}
+	for _, standing := range computeStandings(t) {
+		ids = append(ids, standing.Participant.ID)
+	}
+	var bye []Pairing
+	if len(ids)%2 == 1 {
+		index := len(ids) - 1
+		for index > 0 && t.hadBye(ids[index]) {
+			index--
+		}
+		bye = []Pairing{{First: ids[index]}}
+		ids = append(append([]string{}, ids[:index]...), ids[index+1:]...)
+	}
+	pairings, found := pairWithoutRematch(t, ids)
+	if !found {
+		pairings = pairInOrder(ids)
+	}
+	for index, pairing := range pairings {
+		if t.startsCount(pairing.Second) < t.startsCount(pairing.First) {
+			pairings[index] = Pairing{First: pairing.Second, Second: pairing.First}
+		}
+	}
+	return append(pairings, bye...)
+}
+
+// pairWithoutRematch pair the first participant with the closest one not met yet, backtracking when stuck
+func pairWithoutRematch(t Tournament, ids []string) ([]Pairing, bool) {
+	if len(ids) == 0 {
+		return []Pairing{}, true
+	}
+	first := ids[0]
+	for index := 1; index < len(ids); index++ {
+		if t.havePlayed(first, ids[index]) {
+			continue
+		}
+		rest := append(append([]string{}, ids[1:index]...), ids[index+1:]...)
+		pairings, found := pairWithoutRematch(t, rest)
+		if found {
+			return append([]Pairing{{First: first, Second: ids[index]}}, pairings...), true
+		}
+	}
+	return nil, false
+}
+
+func pairInOrder(ids []string) []Pairing {
+	pairings := []Pairing{}
+	for index := 0; index+1 < len(ids); index += 2 {
+		pairings = append(pairings, Pairing{First: ids[index], Second: ids[index+1]})
+	}
+	return pairings
+}
+
+func (t Tournament) havePlayed(first string, second string) bool {
+	for _, round := range t.Rounds {
+		for _, pairing := range round.Pairings {
+			if (pairing.First == first && pairing.Second == second) || (pairing.First == second && pairing.Second == first) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t Tournament) hadBye(id string) bool {
+	for _, round := range t.Rounds {
+		for _, pairing := range round.Pairings {
+			if pairing.isBye() && pairing.First == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// startsCount get the number of games the participant has started
+func (t Tournament) startsCount(id string) int {
+	count := 0
+	for _, round := range t.Rounds {
+		for _, pairing := range round.Pairings {
+			if !pairing.isBye() && pairing.First == id {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,89 @@
+package tournament
+
+import (
+	"sort"
+)
+
+// Standing is the score of a participant, ties being broken by the Buchholz then the Sonneborn-Berger scores
+type Standing struct {
+	Rank            int         `json:"rank"`
+	Participant     Participant `json:"participant"`
+	Points          float64     `json:"points"`
+	Buchholz        float64     `json:"buchholz"`
+	SonnebornBerger float64     `json:"sonnebornBerger"`
+	Wins            int         `json:"wins"`
+	Losses          int         `json:"losses"`
+	Byes            int         `json:"byes"`
+}
+
+// GetStandings get the standings of the tournament, the best first
+func GetStandings(id string) ([]Standing, error) {
+	t, err := findTournament(id)
+	if err != nil {
+		return []Standing{}, err
+	}
+	return computeStandings(t), nil
+}
+
+func computeStandings(t Tournament) []Standing {
+	standings := make(map[string]*Standing)
+	for _, participant := range t.Participants {
+		standings[participant.ID] = &Standing{Participant: participant}
+	}
+	for _, round := range t.Rounds {
+		for _, pairing := range round.Pairings {
+			if pairing.isBye() {
+				standings[pairing.First].Points++
+				standings[pairing.First].Byes++
+				continue
+			}
+			if pairing.Winner == "" {
+				continue
+			}
+			standings[pairing.Winner].Points++
+			standings[pairing.Winner].Wins++
+			standings[pairing.loser()].Losses++
+		}
+	}
+	for _, round := range t.Rounds {
+		for _, pairing := range round.Pairings {
+			if pairing.isBye() || pairing.Winner == "" {
+				continue
+			}
+			standings[pairing.First].Buchholz += standings[pairing.Second].Points
+			standings[pairing.Second].Buchholz += standings[pairing.First].Points
+			standings[pairing.Winner].SonnebornBerger += standings[pairing.loser()].Points
+		}
+	}
+	ranked := []Standing{}
+	for _, participant := range t.Participants {
+		ranked = append(ranked, *standings[participant.ID])
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].isAhead(ranked[j])
+	})
+	for index := range ranked {
+		ranked[index].Rank = index + 1
+		if index > 0 && !ranked[index-1].isAhead(ranked[index]) {
+			ranked[index].Rank = ranked[index-1].Rank
+		}
+	}
+	return ranked
+}
+
+func (s Standing) isAhead(other Standing) bool {
+	if s.Points != other.Points {
+		return s.Points > other.Points
+	}
+	if s.Buchholz != other.Buchholz {
+		return s.Buchholz > other.Buchholz
+	}
+	return s.SonnebornBerger > other.SonnebornBerger
+}
+
+func (p Pairing) loser() string {
+	if p.Winner == p.First {
+		return p.Second
+	}
+	return p.First
+}
@@ -0,0 +1,454 @@
+package tournament
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"quoridor/controller"
+	"quoridor/exception"
+	"quoridor/game"
+	"quoridor/storage"
+
+	"github.com/lithammer/shortuuid"
+)
+
+// Format is the way the participants are paired
+type Format string
+
+const (
+	ROUND_ROBIN Format = "round-robin"
+	SWISS       Format = "swiss"
+)
+
+// Status is the progress of a tournament
+type Status string
+
+const (
+	REGISTERING Status = "registering"
+	RUNNING     Status = "running"
+	FINISHED    Status = "finished"
+)
+
+// Storage key prefixes, keeping the tournaments apart from the games
+const (
+	tournamentPrefix = "tournament:"
+	gamePrefix       = "tournament-game:"
+)
+
+// TokenLength is the number of random bytes of a participant or organizer token
+const TokenLength = 16
+
+// DefaultRoundMinutes is the time given to play each round, 24 hours
+const DefaultRoundMinutes = 24 * 60
+
+// CheckInterval is how often the deadlines of the rounds are checked
+const CheckInterval = time.Minute
+
+// Settings are what the organizer gives to create a tournament
+type Settings struct {
+	Name          string             `json:"name"`
+	Format        Format             `json:"format"`
+	Rounds        int                `json:"rounds"`
+	Configuration game.Configuration `json:"configuration"`
+	// RoundMinutes is the time given to play each round, DefaultRoundMinutes by default
+	RoundMinutes int `json:"roundMinutes"`
+}
+
+// Participant is a player registered to a tournament, bound to a user if logged in
+type Participant struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	UserID string `json:"userId,omitempty"`
+	token  string
+}
+
+// Registration is the participant with the token to get its seats
+type Registration struct {
+	Participant Participant `json:"participant"`
+	Token       string      `json:"token"`
+}
+
+// Pairing is a game between two participants, or a bye when there is no second participant
+type Pairing struct {
+	First  string `json:"first"`
+	Second string `json:"second,omitempty"`
+	GameID string `json:"gameId,omitempty"`
+	Winner string `json:"winner,omitempty"`
+}
+
+// Round is a set of pairings played at the same time, the games still playing at the deadline being forfeited
+type Round struct {
+	Number   int       `json:"number"`
+	Pairings []Pairing `json:"pairings"`
+	Deadline time.Time `json:"deadline"`
+}
+
+type Tournament struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	Format         Format             `json:"format"`
+	Status         Status             `json:"status"`
+	Configuration  game.Configuration `json:"configuration"`
+	NumberOfRounds int                `json:"numberOfRounds"`
+	Participants   []Participant      `json:"participants"`
+	Rounds         []Round            `json:"rounds"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	RoundMinutes   int                `json:"roundMinutes"`
+	// Error is why the next round could not be created, the creation being tried again
+	Error          string `json:"error,omitempty"`
+	organizerToken string
+}
+
+// Creation is the created tournament with the token of its organizer, required to start it
+type Creation struct {
+	Tournament
+	OrganizerToken string `json:"organizerToken"`
+}
+
+func (p Pairing) isBye() bool {
+	return p.Second == ""
+}
+
+func (p Pairing) isOver() bool {
+	return p.isBye() || p.Winner != ""
+}
+
+// locker serializes the changes of the tournaments, made by the API and by the ends of the games
+var locker sync.Mutex
+
+var register sync.Once
+
+// Init collect the results of the tournament games as soon as they end, and check the deadlines of the rounds
+func Init() {
+	register.Do(func() {
+		gamecontroller.OnGameOver(Record)
+		go func() {
+			for now := range time.Tick(CheckInterval) {
+				Supervise(now)
+			}
+		}()
+	})
+}
+
+func findTournament(id string) (Tournament, error) {
+	t, found := storage.Get(tournamentPrefix + id)
+	if !found {
+		return Tournament{}, exception.New(exception.NOT_FOUND, "The tournament does not exist")
+	}
+	return t.(Tournament), nil
+}
+
+func save(t Tournament) {
+	storage.Set(tournamentPrefix+t.ID, t)
+}
+
+// Create open the registrations of a tournament, the games being played with the configuration
+func Create(settings Settings) (Creation, error) {
+	if settings.Format != ROUND_ROBIN && settings.Format != SWISS {
+		return Creation{}, exception.New(exception.INVALID_CONFIGURATION, "The format must be round-robin or swiss")
+	}
+	if settings.Rounds < 0 {
+		return Creation{}, exception.New(exception.INVALID_CONFIGURATION, "The number of rounds must be positive")
+	}
+	if settings.RoundMinutes < 0 {
+		return Creation{}, exception.New(exception.INVALID_CONFIGURATION, "The time of a round must be positive")
+	}
+	if settings.RoundMinutes == 0 {
+		settings.RoundMinutes = DefaultRoundMinutes
+	}
+	conf := settings.Configuration
+	if conf.BoardSize == 0 && conf.Variant == "" {
+		conf.BoardSize = game.DefaultBoardSize
+	}
+	if conf.NumberOfFencesPerPawnPlayer == 0 && conf.Variant == "" {
+		conf.NumberOfFencesPerPawnPlayer = game.DefaultNumberOfFences
+	}
+	ruleset, err := game.GetRuleset(conf.Variant)
+	if err != nil {
+		return Creation{}, err
+	}
+	conf, err = ruleset.Configure(conf)
+	if err != nil {
+		return Creation{}, err
+	}
+	if _, err := game.NewGameFromConfiguration(conf); err != nil {
+		return Creation{}, err
+	}
+	token, err := newToken()
+	if err != nil {
+		return Creation{}, err
+	}
+	t := Tournament{shortuuid.New(), settings.Name, settings.Format, REGISTERING, conf, settings.Rounds, []Participant{}, []Round{}, time.Now(), settings.RoundMinutes, "", token}
+	save(t)
+	return Creation{t, token}, nil
+}
+
+// Get get the tournament via its identifier
+func Get(id string) (Tournament, error) {
+	return findTournament(id)
+}
+
+// List get all the tournaments, the most recent first
+func List() []Tournament {
+	tournaments := []Tournament{}
+	for _, value := range storage.List() {
+		if t, isTournament := value.(Tournament); isTournament {
+			tournaments = append(tournaments, t)
+		}
+	}
+	sort.SliceStable(tournaments, func(i, j int) bool {
+		if tournaments[i].CreatedAt.Equal(tournaments[j].CreatedAt) {
+			return tournaments[i].ID < tournaments[j].ID
+		}
+		return tournaments[i].CreatedAt.After(tournaments[j].CreatedAt)
+	})
+	return tournaments
+}
+
+// Register add a participant while the registrations are open
+func Register(id string, name string, userID string) (Registration, error) {
+	locker.Lock()
+	defer locker.Unlock()
+	t, err := findTournament(id)
+	if err != nil {
+		return Registration{}, err
+	}
+	if t.Status != REGISTERING {
+		return Registration{}, exception.New(exception.CONFLICT, "The registrations are closed")
+	}
+	if strings.TrimSpace(name) == "" {
+		return Registration{}, exception.New(exception.INVALID_REQUEST, "The name is required")
+	}
+	for _, participant := range t.Participants {
+		if userID != "" && participant.UserID == userID {
+			return Registration{}, exception.New(exception.CONFLICT, "You are already registered")
+		}
+	}
+	token, err := newToken()
+	if err != nil {
+		return Registration{}, err
+	}
+	participant := Participant{shortuuid.New(), strings.TrimSpace(name), userID, token}
+	t.Participants = append(t.Participants, participant)
+	save(t)
+	return Registration{participant, token}, nil
+}
+
+// Start close the registrations and play the first round, on behalf of the organizer owning the token
+func Start(id string, organizerToken string) (Tournament, error) {
+	locker.Lock()
+	defer locker.Unlock()
+	t, err := findTournament(id)
+	if err != nil {
+		return Tournament{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(t.organizerToken), []byte(organizerToken)) != 1 {
+		return Tournament{}, exception.New(exception.UNAUTHORIZED, "The organizer token is invalid")
+	}
+	if t.Status != REGISTERING {
+		return Tournament{}, exception.New(exception.CONFLICT, "The tournament has already started")
+	}
+	if len(t.Participants) < 2 {
+		return Tournament{}, exception.New(exception.CONFLICT, "The tournament needs at least 2 participants")
+	}
+	if t.Format == ROUND_ROBIN {
+		t.NumberOfRounds = len(roundRobinSchedule(t.Participants))
+	} else if t.NumberOfRounds == 0 {
+		t.NumberOfRounds = int(math.Ceil(math.Log2(float64(len(t.Participants)))))
+	}
+	t.Status = RUNNING
+	t, err = startRound(t)
+	if err != nil {
+		return Tournament{}, err
+	}
+	save(t)
+	return t, nil
+}
+
+// startRound pair the participants and create the games of the next round, the participants being seated.
+// The games already created are deleted when the round cannot be created.
+func startRound(t Tournament) (Tournament, error) {
+	var pairings []Pairing
+	if t.Format == ROUND_ROBIN {
+		pairings = roundRobinSchedule(t.Participants)[len(t.Rounds)]
+	} else {
+		pairings = swissPairings(t)
+	}
+	created := []string{}
+	for index, pairing := range pairings {
+		if pairing.isBye() {
+			continue
+		}
+		gameID, err := createGame(t, pairing)
+		if err != nil {
+			for _, createdID := range created {
+				storage.Delete(gamePrefix + createdID)
+				gamecontroller.DeleteGame(createdID)
+			}
+			return Tournament{}, err
+		}
+		created = append(created, gameID)
+		pairings[index].GameID = gameID
+		storage.Set(gamePrefix+gameID, t.ID)
+	}
+	deadline := time.Now().Add(time.Duration(t.RoundMinutes) * time.Minute)
+	t.Rounds = append(t.Rounds, Round{len(t.Rounds) + 1, pairings, deadline})
+	return t, nil
+}
+
+func createGame(t Tournament, pairing Pairing) (string, error) {
+	g, err := gamecontroller.CreateGame(t.Configuration)
+	if err != nil {
+		return "", err
+	}
+	for _, participantID := range []string{pairing.First, pairing.Second} {
+		if _, err := gamecontroller.TakeSeat(g.ID, t.participant(participantID).UserID); err != nil {
+			gamecontroller.DeleteGame(g.ID)
+			return "", err
+		}
+	}
+	return g.ID, nil
+}
+
+// advance play the next round once the current one is over, or finish the tournament after the last round.
+// When the next round cannot be created, the error is kept in the tournament and Supervise tries again.
+func advance(t Tournament) Tournament {
+	if !t.Rounds[len(t.Rounds)-1].isOver() {
+		return t
+	}
+	if len(t.Rounds) == t.NumberOfRounds {
+		t.Status = FINISHED
+		return t
+	}
+	next, err := startRound(t)
+	if err != nil {
+		log.Printf("The round %v of the tournament %v cannot be created: %v", len(t.Rounds)+1, t.ID, err)
+		t.Error = err.Error()
+		return t
+	}
+	next.Error = ""
+	return next
+}
+
+// Supervise create the rounds which could not be created yet, and forfeit the games of the rounds past their deadline,
+// the pawn to play losing
+func Supervise(now time.Time) {
+	forfeits := []string{}
+	locker.Lock()
+	for _, t := range List() {
+		if t.Status != RUNNING || len(t.Rounds) == 0 {
+			continue
+		}
+		if advanced := advance(t); advanced.Status != t.Status || len(advanced.Rounds) != len(t.Rounds) || advanced.Error != t.Error {
+			save(advanced)
+			continue
+		}
+		round := t.Rounds[len(t.Rounds)-1]
+		if now.Before(round.Deadline) {
+			continue
+		}
+		for _, pairing := range round.Pairings {
+			if !pairing.isOver() {
+				forfeits = append(forfeits, pairing.GameID)
+			}
+		}
+	}
+	locker.Unlock()
+	for _, gameID := range forfeits {
+		if g, err := gamecontroller.GetGame(gameID); err == nil && !g.Over {
+			gamecontroller.Resign(gameID, g.PawnTurn)
+		}
+	}
+}
+
+// Record set the winner of the tournament game, then play the next round once every game of the round has ended
+func Record(result gamecontroller.GameResult) {
+	tournamentID, found := storage.Get(gamePrefix + result.GameID)
+	if !found {
+		return
+	}
+	locker.Lock()
+	defer locker.Unlock()
+	t, err := findTournament(tournamentID.(string))
+	if err != nil || t.Status != RUNNING {
+		return
+	}
+	last := len(t.Rounds) - 1
+	round := Round{t.Rounds[last].Number, append([]Pairing{}, t.Rounds[last].Pairings...), t.Rounds[last].Deadline}
+	for index, pairing := range round.Pairings {
+		if pairing.GameID != result.GameID {
+			continue
+		}
+		if result.Winner == 1 {
+			round.Pairings[index].Winner = pairing.First
+		} else {
+			round.Pairings[index].Winner = pairing.Second
+		}
+	}
+	t.Rounds = append(t.Rounds[:last:last], round)
+	save(advance(t))
+}
+
+func (r Round) isOver() bool {
+	for _, pairing := range r.Pairings {
+		if !pairing.isOver() {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Tournament) participant(id string) Participant {
+	for _, participant := range t.Participants {
+		if participant.ID == id {
+			return participant
+		}
+	}
+	return Participant{}
+}
+
+// GetSeat get the game of the current round of the participant owning the token, with its seat number
+func GetSeat(id string, token string) (string, int, error) {
+	t, err := findTournament(id)
+	if err != nil {
+		return "", 0, err
+	}
+	var participant Participant
+	for _, candidate := range t.Participants {
+		if subtle.ConstantTimeCompare([]byte(candidate.token), []byte(token)) == 1 {
+			participant = candidate
+		}
+	}
+	if participant.ID == "" {
+		return "", 0, exception.New(exception.UNAUTHORIZED, "The participant token is invalid")
+	}
+	if len(t.Rounds) > 0 {
+		for _, pairing := range t.Rounds[len(t.Rounds)-1].Pairings {
+			if pairing.isOver() {
+				continue
+			}
+			if pairing.First == participant.ID {
+				return pairing.GameID, 1, nil
+			}
+			if pairing.Second == participant.ID {
+				return pairing.GameID, 2, nil
+			}
+		}
+	}
+	return "", 0, exception.New(exception.NOT_FOUND, "You have no game to play")
+}
+
+func newToken() (string, error) {
+	bytes := make([]byte, TokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
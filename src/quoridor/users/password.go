@@ -0,0 +1,45 @@
+package users
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// PBKDF2 parameters of the password hashes
+const (
+	HashIterations = 10000
+	SaltLength     = 16
+)
+
+func newSalt() ([]byte, error) {
+	salt := make([]byte, SaltLength)
+	_, err := rand.Read(salt)
+	return salt, err
+}
+
+// hashPassword derive the key of the password with PBKDF2-HMAC-SHA256, on a single block
+func hashPassword(password string, salt []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(password))
+	blockIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(blockIndex, 1)
+	mac.Write(salt)
+	mac.Write(blockIndex)
+	u := mac.Sum(nil)
+	key := append([]byte{}, u...)
+	for i := 1; i < HashIterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(nil)
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
+
+func checkPassword(password string, salt []byte, hash []byte) bool {
+	return subtle.ConstantTimeCompare(hashPassword(password, salt), hash) == 1
+}
@@ -0,0 +1,142 @@
+package users
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"quoridor/exception"
+	"quoridor/storage"
+
+	"github.com/lithammer/shortuuid"
+)
+
+// Account rules
+const (
+	MinPasswordLength = 8
+	SessionDuration   = 30 * 24 * time.Hour
+	TokenLength       = 32
+)
+
+// Storage key prefixes, keeping the users apart from the games
+const (
+	userPrefix    = "user:"
+	namePrefix    = "username:"
+	sessionPrefix = "session:"
+)
+
+// User is a registered player
+type User struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"createdAt"`
+	salt         []byte
+	passwordHash []byte
+}
+
+// Credentials are what a user gives to register or log in
+type Credentials struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// Session is the bearer token of a logged in user
+type Session struct {
+	Token     string    `json:"token"`
+	User      User      `json:"user"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type session struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// registration avoids two users taking the same name at once
+var registration sync.Mutex
+
+// Register create a user with a unique name
+func Register(credentials Credentials) (User, error) {
+	name := strings.TrimSpace(credentials.Name)
+	if name == "" {
+		return User{}, exception.New(exception.INVALID_REQUEST, "The name is required")
+	}
+	if len(credentials.Password) < MinPasswordLength {
+		return User{}, exception.New(exception.INVALID_REQUEST, fmt.Sprintf("The password must have at least %v characters", MinPasswordLength))
+	}
+	salt, err := newSalt()
+	if err != nil {
+		return User{}, err
+	}
+	registration.Lock()
+	defer registration.Unlock()
+	if _, taken := storage.Get(namePrefix + strings.ToLower(name)); taken {
+		return User{}, exception.New(exception.CONFLICT, "The name is already taken")
+	}
+	user := User{shortuuid.New(), name, time.Now(), salt, hashPassword(credentials.Password, salt)}
+	storage.Set(userPrefix+user.ID, user)
+	storage.Set(namePrefix+strings.ToLower(name), user.ID)
+	return user, nil
+}
+
+// GetUser get the user via its identifier
+func GetUser(userID string) (User, error) {
+	user, found := storage.Get(userPrefix + userID)
+	if !found {
+		return User{}, exception.New(exception.NOT_FOUND, "The user does not exist")
+	}
+	return user.(User), nil
+}
+
+// Login open a session for the user, the same error is returned for an unknown name or a wrong password
+func Login(credentials Credentials) (Session, error) {
+	invalid := exception.New(exception.UNAUTHORIZED, "Invalid name or password")
+	userID, found := storage.Get(namePrefix + strings.ToLower(strings.TrimSpace(credentials.Name)))
+	if !found {
+		return Session{}, invalid
+	}
+	user, err := GetUser(userID.(string))
+	if err != nil || !checkPassword(credentials.Password, user.salt, user.passwordHash) {
+		return Session{}, invalid
+	}
+	token, err := newToken()
+	if err != nil {
+		return Session{}, err
+	}
+	expiresAt := time.Now().Add(SessionDuration)
+	storage.Set(sessionPrefix+token, session{user.ID, expiresAt})
+	return Session{token, user, expiresAt}, nil
+}
+
+// Authenticate get the user of the session token
+func Authenticate(token string) (User, error) {
+	value, found := storage.Get(sessionPrefix + token)
+	if token == "" || !found {
+		return User{}, exception.New(exception.UNAUTHORIZED, "Authentication required")
+	}
+	s := value.(session)
+	if time.Now().After(s.expiresAt) {
+		return User{}, exception.New(exception.UNAUTHORIZED, "The session has expired")
+	}
+	return GetUser(s.userID)
+}
+
+// Logout close the session
+func Logout(token string) error {
+	if _, err := Authenticate(token); err != nil {
+		return err
+	}
+	storage.Delete(sessionPrefix + token)
+	return nil
+}
+
+func newToken() (string, error) {
+	bytes := make([]byte, TokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,77 @@
+package arena
+
+import (
+	"testing"
+	"time"
+
+	"quoridor/arena"
+	"quoridor/engine"
+	"quoridor/game"
+)
+
+func factory(e func() engine.Engine) engine.Factory {
+	return func() (engine.Engine, error) {
+		return e(), nil
+	}
+}
+
+func TestRunShouldAlternateWhoStartsOnEachBoardSize(t *testing.T) {
+	//Given
+	greedy := factory(func() engine.Engine { return engine.Greedy{} })
+	random := factory(func() engine.Engine { return engine.NewRandom(1) })
+	//When
+	report, err := arena.Run([2]engine.Factory{greedy, random}, arena.Config{Games: 8, BoardSizes: []int{5, 7}, Concurrency: 2})
+	//Then
+	if err != nil {
+		t.Errorf("The match should be played: %s", err.Error())
+		return
+	}
+	starts := make(map[int][2]int)
+	for _, result := range report.Results {
+		count := starts[result.BoardSize]
+		count[result.Starter]++
+		starts[result.BoardSize] = count
+	}
+	if starts[5] != [2]int{2, 2} || starts[7] != [2]int{2, 2} {
+		t.Errorf("Each engine should start the same number of games on each board: %v", starts)
+	}
+	if report.Engines[0].Wins+report.Engines[0].Losses+report.Engines[0].Draws != 8 || report.AverageLength == 0 {
+		t.Errorf("Every game should be counted: %v", report)
+	}
+}
+
+func TestRunShouldReportAConfidenceInterval(t *testing.T) {
+	//Given
+	greedy := factory(func() engine.Engine { return engine.Greedy{} })
+	random := factory(func() engine.Engine { return engine.NewRandom(2) })
+	//When
+	report, _ := arena.Run([2]engine.Factory{greedy, random}, arena.Config{Games: 10, BoardSizes: []int{5}})
+	//Then
+	stats := report.Engines[0]
+	if stats.Low > stats.Score || stats.High < stats.Score || stats.High > 1 || stats.Low < 0 {
+		t.Errorf("The score should be in its interval: %v", stats)
+	}
+	if stats.Moves == 0 || stats.MaxTime < stats.AverageTime() {
+		t.Errorf("The moves should be timed: %v", stats)
+	}
+}
+
+type illegalEngine struct{}
+
+func (illegalEngine) Name() string { return "illegal" }
+func (illegalEngine) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	return game.NewMoveAction(game.Position{4, 4}), nil
+}
+func (illegalEngine) Close() error { return nil }
+
+func TestRunShouldForfeitAnIllegalAction(t *testing.T) {
+	//Given
+	illegal := factory(func() engine.Engine { return illegalEngine{} })
+	greedy := factory(func() engine.Engine { return engine.Greedy{} })
+	//When
+	report, _ := arena.Run([2]engine.Factory{illegal, greedy}, arena.Config{Games: 2, BoardSizes: []int{5}})
+	//Then
+	if report.Engines[0].Forfeits != 2 || report.Engines[1].Wins != 2 {
+		t.Errorf("The illegal engine should lose by forfeit: %v", report.Engines)
+	}
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"quoridor/auth"
+	"quoridor/exception"
+)
+
+func TestVerifyShouldGetTheClaimsOfAnIssuedToken(t *testing.T) {
+	//Given
+	token, _ := auth.Issue("azerty", 2)
+	//When
+	claims, err := auth.Verify(token)
+	//Then
+	if err != nil || claims.GameID != "azerty" || claims.Number != 2 {
+		t.Errorf("Not the right claims: %v %v", claims, err)
+	}
+}
+
+func TestVerifyShouldRejectATamperedToken(t *testing.T) {
+	//Given
+	token, _ := auth.Issue("azerty", 1)
+	forged, _ := auth.Sign(auth.NewClaims("azerty", 2))
+	parts := strings.Split(token, ".")
+	forgedParts := strings.Split(forged, ".")
+	tampered := parts[0] + "." + forgedParts[1] + "." + parts[2]
+	//When
+	_, err := auth.Verify(tampered)
+	//Then
+	if !exception.MatchGameError(err, exception.UNAUTHORIZED) {
+		t.Errorf("The token should be rejected: %v", err)
+	}
+}
+
+func TestVerifyShouldRejectAnExpiredToken(t *testing.T) {
+	//Given
+	claims := auth.NewClaims("azerty", 1)
+	claims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	token, _ := auth.Sign(claims)
+	//When
+	_, err := auth.Verify(token)
+	//Then
+	if err == nil || err.Error() != "The token has expired" {
+		t.Errorf("The token should be expired: %v", err)
+	}
+}
+
+func TestVerifyShouldRejectATokenSignedWithAnotherSecret(t *testing.T) {
+	//Given
+	token, _ := auth.Issue("azerty", 1)
+	//When
+	auth.Configure("another secret")
+	_, err := auth.Verify(token)
+	//Then
+	if err == nil {
+		t.Error("The token should be rejected")
+	}
+}
@@ -0,0 +1,74 @@
+package boardimage
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"quoridor/boardimage"
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+func TestSVGShouldDrawThePawnsAndTheFences(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.AddFence(game.Fence{game.Position{1, 1}, true})
+	//When
+	svg := string(boardimage.SVG(g, boardimage.Options{}))
+	//Then
+	if strings.Count(svg, "<circle") != 2 || strings.Count(svg, `fill="#3b2a1a"`) != 1 || !strings.Contains(svg, ">e</text>") {
+		t.Errorf("Not the right drawing: %v", svg)
+	}
+	if strings.Contains(svg, "<line") {
+		t.Error("The last move should not be drawn without the option")
+	}
+}
+
+func TestSVGShouldDrawAnArrowForTheLastMove(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.MovePawn(game.Position{1, 2})
+	//When
+	svg := string(boardimage.SVG(g, boardimage.Options{true}))
+	//Then
+	if !strings.Contains(svg, `<line x1="50" y1="150"`) {
+		t.Errorf("The arrow should start from the previous square: %v", svg)
+	}
+}
+
+func TestPNGShouldDrawThePawnsInTheirColors(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	//When
+	data, err := boardimage.PNG(g, boardimage.Options{})
+	//Then
+	if err != nil {
+		t.Fatalf("The image should be drawn: %s", err.Error())
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("The image should be a PNG: %s", err.Error())
+	}
+	size := 2*boardimage.Margin + 5*boardimage.SquareSize + 4*boardimage.GapSize
+	if img.Bounds().Dx() != size || img.Bounds().Dy() != size {
+		t.Errorf("Not the right size: %v", img.Bounds())
+	}
+	r, _, b, _ := img.At(50, 150).RGBA()
+	if r>>8 != 0xd6 || b>>8 != 0x28 {
+		t.Errorf("The first pawn should be red: %v", img.At(50, 150))
+	}
+}
+
+func TestPNGShouldRejectTheBoardsTooLargeToDraw(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g.Board = &game.Board{boardimage.MaxBoardSize + 2, nil, game.Positions{}}
+	//When
+	_, err := boardimage.PNG(g, boardimage.Options{})
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The board should be too large to draw: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package boardimage
+
+import (
+	"bytes"
+	"image/gif"
+	"strings"
+	"testing"
+	"time"
+
+	"quoridor/boardimage"
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+func playedPositions(t *testing.T) []game.Game {
+	g, _ := game.NewGame(5)
+	g, _ = g.MovePawn(game.Position{1, 2})
+	g, _ = g.AddFence(game.Fence{game.Position{0, 0}, true})
+	positions, err := game.Replay(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 10}, g.ID, g.History)
+	if err != nil {
+		t.Fatalf("The game should be replayed: %s", err.Error())
+	}
+	return positions
+}
+
+func TestGIFShouldAnimateEveryPly(t *testing.T) {
+	//Given
+	positions := playedPositions(t)
+	//When
+	data, err := boardimage.GIF(positions, 500*time.Millisecond)
+	//Then
+	if err != nil {
+		t.Fatalf("The replay should be drawn: %s", err.Error())
+	}
+	animation, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil || len(animation.Image) != 3 || animation.Delay[0] != 50 || animation.Delay[2] != 50*boardimage.FinalFrameFactor {
+		t.Errorf("Not the right animation: %v", err)
+	}
+}
+
+func TestHTMLShouldEmbedAFramePerPly(t *testing.T) {
+	//Given
+	positions := playedPositions(t)
+	//When
+	data, err := boardimage.HTML(positions, 500*time.Millisecond)
+	//Then
+	page := string(data)
+	if err != nil || strings.Count(page, "<svg") != 3 || !strings.Contains(page, "var delay = 500;") || !strings.Contains(page, `url(#head-1)`) {
+		t.Errorf("Not the right page: %v", page)
+	}
+}
+
+func TestGIFShouldRejectAReplayTooLargeToDraw(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(game.MaxBoardSize)
+	positions := make([]game.Game, boardimage.MaxReplayPlies)
+	for index := range positions {
+		positions[index] = g
+	}
+	//When
+	_, err := boardimage.GIF(positions, 500*time.Millisecond)
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The replay should be too large: %v", err)
+	}
+}
+
+func TestCheckPliesShouldRejectTheLongGames(t *testing.T) {
+	//Given
+	//When
+	err := boardimage.CheckPlies(boardimage.MaxReplayPlies + 1)
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The game should be too long to export: %v", err)
+	}
+}
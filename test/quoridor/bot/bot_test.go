@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"quoridor/bot"
+	"quoridor/controller"
+	"quoridor/engine"
+	"quoridor/game"
+	"quoridor/storage"
+)
+
+type crashingEngine struct{}
+
+func (crashingEngine) Name() string {
+	return "crashing"
+}
+
+func (crashingEngine) BestAction(g game.Game, moveTime time.Duration) (game.Action, error) {
+	return game.Action{}, engine.ErrCrashed
+}
+
+func (crashingEngine) Close() error {
+	return nil
+}
+
+func startGame(t *testing.T) *game.Game {
+	storage.Init()
+	g, err := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	if err != nil {
+		t.Fatalf("The game should be created: %s", err.Error())
+	}
+	gamecontroller.TakeSeat(g.ID, "")
+	gamecontroller.TakeSeat(g.ID, "")
+	return g
+}
+
+func TestPlaySeatShouldPlayUntilTheGameIsOver(t *testing.T) {
+	//Given
+	g := startGame(t)
+	greedy, _ := engine.NewFactory(engine.GREEDY_ENGINE)
+	config := bot.Config{10 * time.Millisecond, 0, 0}
+	done := make(chan error, 2)
+	//When
+	go func() { done <- bot.PlaySeat(greedy, g.ID, 1, config, nil) }()
+	go func() { done <- bot.PlaySeat(greedy, g.ID, 2, config, nil) }()
+	//Then
+	for index := 0; index < 2; index++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("The bot should play legal actions: %s", err.Error())
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("The game should be over")
+		}
+	}
+	played, _ := gamecontroller.GetGame(g.ID)
+	if !played.Over || played.Resigned != 0 {
+		t.Errorf("The game should be won on the board: %v", played)
+	}
+}
+
+func TestPlaySeatShouldResignWhenTheEngineKeepsCrashing(t *testing.T) {
+	//Given
+	g := startGame(t)
+	starts := 0
+	crashing := func() (engine.Engine, error) {
+		starts++
+		return crashingEngine{}, nil
+	}
+	//When
+	err := bot.PlaySeat(crashing, g.ID, 1, bot.Config{10 * time.Millisecond, 2, 0}, nil)
+	//Then
+	played, _ := gamecontroller.GetGame(g.ID)
+	if err != engine.ErrCrashed || starts != 3 {
+		t.Errorf("The engine should be restarted twice: %v %v", starts, err)
+	}
+	if !played.Over || played.Winner() != 2 {
+		t.Errorf("The bot should resign: %v", played)
+	}
+}
+
+func TestAddShouldRejectAnUnknownEngine(t *testing.T) {
+	//Given
+	g := startGame(t)
+	bot.Configure("")
+	//When
+	_, err := bot.Add(g.ID, "stockfish")
+	//Then
+	if err == nil {
+		t.Error("The engine should not exist")
+	}
+}
+
+func TestConfigureShouldOfferTheExternalEngines(t *testing.T) {
+	//When
+	err := bot.Configure("reference=quoridor-engine -engine greedy")
+	//Then
+	engines := bot.Engines()
+	if err != nil || len(engines) != 3 || engines[2] != "reference" {
+		t.Errorf("Not the right engines: %v %v", engines, err)
+	}
+}
+
+func TestPlaySeatShouldStartTheEngineOnceTheGameIsPlaying(t *testing.T) {
+	//Given
+	storage.Init()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.TakeSeat(g.ID, "")
+	starts := 0
+	counting := func() (engine.Engine, error) {
+		starts++
+		return crashingEngine{}, nil
+	}
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- bot.PlaySeat(counting, g.ID, 1, bot.Config{10 * time.Millisecond, 0, 0}, stop) }()
+	//When
+	time.Sleep(20 * time.Millisecond)
+	close(stop)
+	//Then
+	if err := <-done; err != nil || starts != 0 {
+		t.Errorf("The engine should not be started while the game is waiting: %v %v", starts, err)
+	}
+}
+
+func TestPlaySeatShouldResignWhenTheGameIsIdle(t *testing.T) {
+	//Given
+	storage.Init()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.TakeSeat(g.ID, "")
+	greedy, _ := engine.NewFactory(engine.GREEDY_ENGINE)
+	//When
+	err := bot.PlaySeat(greedy, g.ID, 1, bot.Config{10 * time.Millisecond, 0, 20 * time.Millisecond}, nil)
+	//Then
+	played, _ := gamecontroller.GetGame(g.ID)
+	if err != bot.ErrIdle || !played.Over {
+		t.Errorf("The bot should resign: %v %v", played, err)
+	}
+}
+
+func TestStopShouldStopTheBotsOfTheGame(t *testing.T) {
+	//Given
+	storage.Init()
+	bot.Configure("")
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	bot.Add(g.ID, engine.GREEDY_ENGINE)
+	stopped := make(chan struct{})
+	//When
+	go func() {
+		bot.Stop(g.ID)
+		close(stopped)
+	}()
+	//Then
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("The bot should be stopped")
+	}
+}
@@ -0,0 +1,200 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"quoridor/bot"
+	"quoridor/controller"
+	"quoridor/engine"
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+var fastWebhook = bot.WebhookConfig{time.Second, 2, time.Millisecond, true}
+
+func TestWebhookShouldPlayTheActionOfTheBot(t *testing.T) {
+	//Given
+	var turn bot.Turn
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&turn)
+		json.NewEncoder(w).Encode(turn.LegalActions[0])
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, fastWebhook)
+	g, _ := game.NewGame(5)
+	//When
+	action, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err != nil || action.Notation() != turn.LegalActions[0].Notation() || turn.Number != 1 {
+		t.Errorf("Not the action of the bot: %v %v", action.Notation(), err)
+	}
+}
+
+func TestWebhookShouldRetryAfterAServerError(t *testing.T) {
+	//Given
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(game.NewMoveAction(game.Position{1, 2}))
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, fastWebhook)
+	g, _ := game.NewGame(5)
+	//When
+	_, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err != nil || atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("The bot should be called again: %v %v", calls, err)
+	}
+}
+
+func TestWebhookShouldNotFollowARedirect(t *testing.T) {
+	//Given
+	var redirected int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&redirected, 1)
+		json.NewEncoder(w).Encode(game.NewMoveAction(game.Position{1, 2}))
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, fastWebhook)
+	g, _ := game.NewGame(5)
+	//When
+	_, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err == nil || atomic.LoadInt32(&redirected) != 0 {
+		t.Errorf("The redirection should not be followed: %v %v", redirected, err)
+	}
+}
+
+func TestWebhookShouldNotReadAnEndlessAnswer(t *testing.T) {
+	//Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type": "move", "position": {"column": 1, "row": 2}, "padding": "`))
+		w.Write(bytes.Repeat([]byte("x"), bot.MaxWebhookResponse))
+		w.Write([]byte(`"}`))
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, fastWebhook)
+	g, _ := game.NewGame(5)
+	//When
+	_, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err == nil {
+		t.Error("The answer should be cut")
+	}
+}
+
+func TestWebhookShouldTimeOut(t *testing.T) {
+	//Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, bot.WebhookConfig{50 * time.Millisecond, 1, time.Millisecond, true})
+	g, _ := game.NewGame(5)
+	//When
+	_, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err == nil {
+		t.Error("The bot should be too slow")
+	}
+}
+
+func TestNewWebhookShouldRejectARelativeURL(t *testing.T) {
+	//When
+	_, err := bot.NewWebhook("/bot", fastWebhook)
+	//Then
+	if err == nil {
+		t.Error("The url should be rejected")
+	}
+}
+
+func TestPlaySeatShouldResignWhenTheWebhookPlaysAnIllegalAction(t *testing.T) {
+	//Given
+	g := startGame(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(game.NewMoveAction(game.Position{4, 4}))
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, fastWebhook)
+	factory := func() (engine.Engine, error) {
+		return webhook, nil
+	}
+	//When
+	err := bot.PlaySeat(factory, g.ID, 1, bot.Config{time.Second, 0, 0}, nil)
+	//Then
+	played, _ := gamecontroller.GetGame(g.ID)
+	if err == nil || !played.Over || played.Winner() != 2 {
+		t.Errorf("The bot should forfeit: %v %v", played, err)
+	}
+}
+
+func TestWebhookShouldNotCallAPrivateAddress(t *testing.T) {
+	//Given
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(game.NewMoveAction(game.Position{1, 2}))
+	}))
+	defer server.Close()
+	webhook, _ := bot.NewWebhook(server.URL, bot.WebhookConfig{time.Second, 0, time.Millisecond, false})
+	g, _ := game.NewGame(5)
+	//When
+	_, err := webhook.BestAction(g, time.Second)
+	//Then
+	if err == nil || atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("The loopback address should not be called: %v %v", calls, err)
+	}
+}
+
+func TestAddRequestedShouldRequireALoggedInUserForAWebhook(t *testing.T) {
+	//Given
+	g := startGame(t)
+	bot.ConfigureWebhooks(bot.AnyHost)
+	defer bot.ConfigureWebhooks("")
+	//When
+	_, err := bot.AddRequested(g.ID, bot.Request{"", "https://bot.example.com/quoridor"}, "")
+	//Then
+	if !exception.MatchGameError(err, exception.UNAUTHORIZED) {
+		t.Errorf("The user should be logged in: %v", err)
+	}
+}
+
+func TestAddWebhookShouldRejectAHostNotAllowed(t *testing.T) {
+	//Given
+	g := startGame(t)
+	bot.ConfigureWebhooks("bot.example.com")
+	defer bot.ConfigureWebhooks("")
+	//When
+	_, err := bot.AddWebhook(g.ID, "http://169.254.169.254/latest/meta-data")
+	//Then
+	if !exception.MatchGameError(err, exception.FORBIDDEN) {
+		t.Errorf("The host should not be allowed: %v", err)
+	}
+}
+
+func TestAddWebhookShouldRejectAnAllowedHostResolvingToAPrivateAddress(t *testing.T) {
+	//Given
+	g := startGame(t)
+	bot.ConfigureWebhooks(bot.AnyHost)
+	defer bot.ConfigureWebhooks("")
+	//When
+	_, err := bot.AddWebhook(g.ID, "http://127.0.0.1:8080/bot")
+	//Then
+	if !exception.MatchGameError(err, exception.INVALID_REQUEST) {
+		t.Errorf("The loopback address should be rejected: %v", err)
+	}
+}
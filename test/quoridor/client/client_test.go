@@ -0,0 +1,81 @@
+package client
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"quoridor/client"
+	"quoridor/game"
+	"quoridor/server"
+	"quoridor/storage"
+)
+
+func newClient(t *testing.T) (*client.Client, func()) {
+	storage.Init()
+	router, err := server.NewRouter()
+	if err != nil {
+		t.Fatalf("the router should be created: %s", err.Error())
+	}
+	s := httptest.NewServer(router)
+	return client.New(s.URL), s.Close
+}
+
+func TestClientShouldPlayAGame(t *testing.T) {
+	//Given
+	c, stop := newClient(t)
+	defer stop()
+	g, _ := c.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	first, _ := c.Join(g.ID)
+	c.Join(g.ID)
+	//When
+	played, err := c.Play(g.ID, first.Token, game.NewMoveAction(game.Position{1, 2}))
+	//Then
+	if err != nil || played.Status != "playing" || played.CurrentPlayer != 2 || played.Players[0].Position != (game.Position{1, 2}) {
+		t.Errorf("The action should be played: %v %v", played, err)
+	}
+}
+
+func TestClientShouldGetAllTheLegalActions(t *testing.T) {
+	//Given
+	c, stop := newClient(t)
+	defer stop()
+	g, _ := c.CreateGame(game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10})
+	c.Join(g.ID)
+	c.Join(g.ID)
+	//When
+	actions, err := c.LegalActions(g.ID)
+	//Then
+	if err != nil || len(actions) != 3+2*8*8 {
+		t.Errorf("Not all the legal actions: %v %v", len(actions), err)
+	}
+}
+
+func TestClientShouldGetTheErrorOfTheServer(t *testing.T) {
+	//Given
+	c, stop := newClient(t)
+	defer stop()
+	g, _ := c.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	c.Join(g.ID)
+	second, _ := c.Join(g.ID)
+	//When
+	_, err := c.Play(g.ID, second.Token, game.NewMoveAction(game.Position{3, 2}))
+	//Then
+	apiError, ok := err.(*client.Error)
+	if !ok || apiError.Code != "not_your_turn" {
+		t.Errorf("Not the error of the server: %v", err)
+	}
+}
+
+func TestClientShouldGetTheTextBoard(t *testing.T) {
+	//Given
+	c, stop := newClient(t)
+	defer stop()
+	g, _ := c.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	//When
+	board, err := c.GetBoard(g.ID, game.RenderOptions{Coordinates: true, ASCII: true})
+	//Then
+	if err != nil || !strings.Contains(board, " 3 1 ") || !strings.HasPrefix(board, "   a   b") {
+		t.Errorf("Not the text board: %v %v", board, err)
+	}
+}
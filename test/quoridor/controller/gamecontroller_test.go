@@ -1,8 +1,11 @@
 package gamecontroller
 
 import (
+	"sync"
 	"testing"
+	"time"
 	"quoridor/controller"
+	"quoridor/exception"
 	"quoridor/game"
 	"quoridor/storage"
 )
@@ -14,7 +17,7 @@ func setUp() {
 func TestCreateGame(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	//When
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	//Then
@@ -26,7 +29,7 @@ func TestCreateGame(t *testing.T) {
 func TestCreateGameShouldNotBePossibleWithEvenNumber(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{8, 10}
+	configuration := game.Configuration{BoardSize: 8, NumberOfFencesPerPawnPlayer: 10}
 	//When
 	_, err := gamecontroller.CreateGame(configuration)
 	//Then
@@ -38,7 +41,7 @@ func TestCreateGameShouldNotBePossibleWithEvenNumber(t *testing.T) {
 func TestCreateGameShouldNotBePossibleWithLessThanThree(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{1, 10}
+	configuration := game.Configuration{BoardSize: 1, NumberOfFencesPerPawnPlayer: 10}
 	//When
 	_, err := gamecontroller.CreateGame(configuration)
 	//Then
@@ -50,7 +53,7 @@ func TestCreateGameShouldNotBePossibleWithLessThanThree(t *testing.T) {
 func TestGetGameShouldRetrieveAnExistingGame(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	//When
 	getGame, _ := gamecontroller.GetGame(newGame.ID)
@@ -85,7 +88,7 @@ func TestGetFencePossibilitiesShouldRaiseAnExceptionWithAnUnknownGame(t *testing
 func TestGetFencePossibilitiesShouldRetrieveAllPossibilities(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	//When
@@ -99,7 +102,7 @@ func TestGetFencePossibilitiesShouldRetrieveAllPossibilities(t *testing.T) {
 func TestGetFencePossibilitiesShouldRetrieveAllPossibilitiesWithAFence(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -115,7 +118,7 @@ func TestGetFencePossibilitiesShouldRetrieveAllPossibilitiesWithAFence(t *testin
 func TestGetFencePossibilitiesShouldRetrievePossibilitiesWithoutFence(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{3, 10}
+	configuration := game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	//When
 	fences, _ := gamecontroller.GetFencePossibilities(newGame.ID)
@@ -128,7 +131,7 @@ func TestGetFencePossibilitiesShouldRetrievePossibilitiesWithoutFence(t *testing
 func TestGetFencePossibilitiesShouldRetrieveAllPossibilitiesWithAFenceWihtoutClosingPath(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{3, 10}
+	configuration := game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -136,15 +139,15 @@ func TestGetFencePossibilitiesShouldRetrieveAllPossibilitiesWithAFenceWihtoutClo
 	//When
 	fences, _ := gamecontroller.GetFencePossibilities(newGame.ID)
 	//Then
-	if len(fences) != 4 {
-		t.Errorf("With one fences, there are 4 possibilities but get %v", len(fences))
+	if len(fences) != 3 {
+		t.Errorf("With one fences, there are 3 possibilities but get %v", len(fences))
 	}
 }
 
 func TestAddFenceNotPossibleWithoutAnOpponent(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	//When
@@ -161,7 +164,7 @@ func TestAddFenceNotPossibleWithoutAnOpponent(t *testing.T) {
 func TestMovePawnNotPossibleWithoutAnOpponent(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	//When
@@ -178,7 +181,7 @@ func TestMovePawnNotPossibleWithoutAnOpponent(t *testing.T) {
 func TestJoinGameShouldAddTheOpponent(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	//When
@@ -192,7 +195,7 @@ func TestJoinGameShouldAddTheOpponent(t *testing.T) {
 func TestJoinGameShouldNotMoreThanExpectedOpponents(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -210,7 +213,7 @@ func TestJoinGameShouldNotMoreThanExpectedOpponents(t *testing.T) {
 func TestAddFenceNotPossibleWithAnUnkownPlayer(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -228,7 +231,7 @@ func TestAddFenceNotPossibleWithAnUnkownPlayer(t *testing.T) {
 func TestMovePawnNotPossibleWithAnUnkownPlayer(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 10}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -246,7 +249,7 @@ func TestMovePawnNotPossibleWithAnUnkownPlayer(t *testing.T) {
 func TestAddFenceNoMore(t *testing.T) {
 	//Given
 	setUp()
-	configuration := game.Configuration{9, 1}
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 1}
 	newGame, _ := gamecontroller.CreateGame(configuration)
 	gamecontroller.JoinGame(newGame.ID, "azerty")
 	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
@@ -262,3 +265,248 @@ func TestAddFenceNoMore(t *testing.T) {
 		t.Errorf("Not the right error: %s", err.Error())
 	}
 }
+
+func TestPlayActionShouldMoveThePawn(t *testing.T) {
+	//Given
+	setUp()
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
+	newGame, _ := gamecontroller.CreateGame(configuration)
+	gamecontroller.JoinGame(newGame.ID, "azerty")
+	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
+	//When
+	g, err := gamecontroller.PlayAction(newGame.ID, game.NewMoveAction(game.Position{1, 4}), "azerty")
+	//Then
+	if err != nil {
+		t.Errorf("It should be possible to move the pawn: %s", err.Error())
+		return
+	}
+	if g.PawnTurn != 2 {
+		t.Error("It should be the turn of the opponent")
+	}
+}
+
+func TestPlayActionNotPossibleWhenItIsNotYourTurn(t *testing.T) {
+	//Given
+	setUp()
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
+	newGame, _ := gamecontroller.CreateGame(configuration)
+	gamecontroller.JoinGame(newGame.ID, "azerty")
+	gamecontroller.JoinGame(newGame.ID, "qsdfgh")
+	//When
+	_, err := gamecontroller.PlayAction(newGame.ID, game.NewFenceAction(game.Fence{game.Position{0, 0}, true}), "qsdfgh")
+	//Then
+	if err == nil {
+		t.Error("It is not possible to play during the opponent turn")
+		return
+	}
+	if err.Error() != "It is not your turn" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestGetGameShouldRaiseANotFoundError(t *testing.T) {
+	//Given
+	setUp()
+	//When
+	_, err := gamecontroller.GetGame("12453po")
+	//Then
+	if !exception.MatchGameError(err, exception.NOT_FOUND) {
+		t.Errorf("Not the right error type: %v", err)
+	}
+}
+
+func TestPlayActionShouldNotifyTheEndOfTheGame(t *testing.T) {
+	//Given
+	setUp()
+	var results []gamecontroller.GameResult
+	gamecontroller.OnGameOver(func(result gamecontroller.GameResult) {
+		results = append(results, result)
+	})
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: 0})
+	gamecontroller.JoinGameAs(g.ID, "azerty", "alice")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 1}), 1)
+	//When
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{0, 1}), 2)
+	//Then
+	if len(results) != 1 {
+		t.Errorf("The end of the game should be notified once: %v", results)
+		return
+	}
+	if results[0].GameID != g.ID || results[0].Winner != 2 || results[0].Users[0] != "alice" || results[0].Users[1] != "" {
+		t.Errorf("Not the right result: %v", results[0])
+	}
+}
+
+func TestConcurrentChangesShouldEndTheGameOnce(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: 0})
+	var lock sync.Mutex
+	ends := 0
+	gamecontroller.OnGameOver(func(result gamecontroller.GameResult) {
+		if result.GameID == g.ID {
+			lock.Lock()
+			ends++
+			lock.Unlock()
+		}
+	})
+	var seating sync.WaitGroup
+	for index := 0; index < 4; index++ {
+		seating.Add(1)
+		go func() {
+			defer seating.Done()
+			gamecontroller.TakeSeat(g.ID, "")
+		}()
+	}
+	seating.Wait()
+	//When
+	var playing sync.WaitGroup
+	for index := 0; index < 8; index++ {
+		playing.Add(1)
+		go func(number int) {
+			defer playing.Done()
+			gamecontroller.Resign(g.ID, number)
+		}(index%2 + 1)
+	}
+	playing.Wait()
+	//Then
+	seats, _ := gamecontroller.GetSeats(g.ID)
+	if len(seats) != 2 || ends != 1 {
+		t.Errorf("The game should have 2 seats and end once: %v %v", seats, ends)
+	}
+}
+
+func TestResignShouldMakeTheOpponentWin(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	//When
+	resigned, err := gamecontroller.Resign(g.ID, 1)
+	//Then
+	if err != nil || !resigned.Over || resigned.Winner() != 2 {
+		t.Errorf("The second pawn should win: %v %v", resigned, err)
+	}
+}
+
+func TestWatchShouldGetTheStateAfterEachAction(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	updates, cancel := gamecontroller.Watch(g.ID)
+	defer cancel()
+	//When
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	//Then
+	select {
+	case update := <-updates:
+		if update.PawnTurn != 2 {
+			t.Errorf("Not the latest state: %v", update)
+		}
+	case <-time.After(time.Second):
+		t.Error("The watcher should get the state")
+	}
+}
+
+func TestGetPositionsShouldEndWithTheCurrentGame(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	gamecontroller.Resign(g.ID, 2)
+	//When
+	positions, err := gamecontroller.GetPositions(g.ID)
+	//Then
+	if err != nil || len(positions) != 2 || positions[0].Pawns[0].Position != (game.Position{0, 2}) || !positions[1].Over || positions[1].Resigned != 2 {
+		t.Errorf("Not the right positions: %v %v", positions, err)
+	}
+}
+
+func TestGetGameAtShouldRejectAPlyNotPlayedYet(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	//When
+	_, err := gamecontroller.GetGameAt(g.ID, 1)
+	//Then
+	if !exception.MatchGameError(err, exception.NOT_FOUND) {
+		t.Errorf("The ply should not exist: %v", err)
+	}
+}
+
+func TestForkGameShouldStartFromThePositionAtThePly(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{3, 2}), 2)
+	//When
+	fork, err := gamecontroller.ForkGame(g.ID, 1, "creator")
+	//Then
+	if err != nil || fork.ID == g.ID || fork.PawnTurn != 2 || fork.Pawns[0].Position != (game.Position{1, 2}) || fork.Pawns[1].Position != (game.Position{4, 2}) {
+		t.Errorf("Not the right fork: %v %v", fork, err)
+	}
+	forkedFrom, _ := gamecontroller.GetForkedFrom(fork.ID)
+	if forkedFrom == nil || *forkedFrom != (gamecontroller.Fork{g.ID, 1}) {
+		t.Errorf("The fork should be derived from the game: %v", forkedFrom)
+	}
+	original, _ := gamecontroller.GetGame(g.ID)
+	if original.Pawns[1].Position != (game.Position{3, 2}) {
+		t.Errorf("The original game should be untouched: %v", original)
+	}
+}
+
+func TestForkGameShouldCountThePliesFromTheForkedPosition(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	fork, _ := gamecontroller.ForkGame(g.ID, 1, "")
+	gamecontroller.JoinGame(fork.ID, "azerty")
+	gamecontroller.JoinGame(fork.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(fork.ID, game.NewMoveAction(game.Position{3, 2}), 2)
+	//When
+	positions, err := gamecontroller.GetPositions(fork.ID)
+	//Then
+	if err != nil || len(positions) != 2 || positions[0].Pawns[0].Position != (game.Position{1, 2}) || positions[0].ID != fork.ID || positions[1].Pawns[1].Position != (game.Position{3, 2}) {
+		t.Errorf("The fork should start from the forked position: %v %v", positions, err)
+	}
+}
+
+func TestForkGameShouldRejectAnOverPosition(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	gamecontroller.Resign(g.ID, 2)
+	//When
+	_, err := gamecontroller.ForkGame(g.ID, 1, "")
+	//Then
+	if !exception.MatchGameError(err, exception.GAME_OVER) {
+		t.Errorf("The game should be over: %v", err)
+	}
+}
+
+func TestDeleteGameShouldRemoveTheGame(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	//When
+	err := gamecontroller.DeleteGame(g.ID)
+	//Then
+	if _, getErr := gamecontroller.GetGame(g.ID); err != nil || !exception.MatchGameError(getErr, exception.NOT_FOUND) {
+		t.Errorf("The game should be deleted: %v %v", err, getErr)
+	}
+}
@@ -0,0 +1,107 @@
+package gamecontroller
+
+import (
+	"testing"
+
+	"quoridor/controller"
+	"quoridor/game"
+)
+
+func TestListGamesShouldListTheGamesWaitingForAPlayer(t *testing.T) {
+	//Given
+	setUp()
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
+	waitingGame, _ := gamecontroller.HostGame(configuration, "alice")
+	playingGame, _ := gamecontroller.CreateGame(configuration)
+	gamecontroller.JoinGame(playingGame.ID, "azerty")
+	gamecontroller.JoinGame(playingGame.ID, "qsdfgh")
+	//When
+	games := gamecontroller.ListGames(gamecontroller.GameFilter{Status: gamecontroller.WAITING})
+	//Then
+	if len(games) != 1 {
+		t.Errorf("Only one game is waiting for a player but get %v", len(games))
+		return
+	}
+	if games[0].ID != waitingGame.ID || games[0].Creator != "alice" || games[0].Configuration.BoardSize != 9 {
+		t.Errorf("Not the right game: %v", games[0])
+	}
+	if games[0].CreatedAt.IsZero() {
+		t.Error("The creation time should be set")
+	}
+}
+
+func TestListGamesShouldFilterByBoardSize(t *testing.T) {
+	//Given
+	setUp()
+	gamecontroller.CreateGame(game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10})
+	smallGame, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 10})
+	//When
+	games := gamecontroller.ListGames(gamecontroller.GameFilter{BoardSize: 5})
+	//Then
+	if len(games) != 1 || games[0].ID != smallGame.ID {
+		t.Errorf("Only the small game should be listed: %v", games)
+	}
+}
+
+func TestListGamesShouldListThePlayingGames(t *testing.T) {
+	//Given
+	setUp()
+	configuration := game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}
+	gamecontroller.CreateGame(configuration)
+	playingGame, _ := gamecontroller.CreateGame(configuration)
+	gamecontroller.JoinGame(playingGame.ID, "azerty")
+	gamecontroller.JoinGame(playingGame.ID, "qsdfgh")
+	//When
+	games := gamecontroller.ListGames(gamecontroller.GameFilter{Status: gamecontroller.PLAYING})
+	//Then
+	if len(games) != 1 || games[0].ID != playingGame.ID || games[0].Players != 2 {
+		t.Errorf("Only the playing game should be listed: %v", games)
+	}
+}
+
+func TestListUserGamesShouldListTheGamesOfTheUser(t *testing.T) {
+	//Given
+	setUp()
+	configuration := game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3}
+	userGame, _ := gamecontroller.CreateGame(configuration)
+	otherGame, _ := gamecontroller.CreateGame(configuration)
+	gamecontroller.JoinGame(userGame.ID, "azerty")
+	gamecontroller.JoinGameAs(userGame.ID, "qsdfgh", "alice")
+	gamecontroller.JoinGame(otherGame.ID, "wxcvbn")
+	//When
+	games := gamecontroller.ListUserGames("alice")
+	//Then
+	if len(games) != 1 || games[0].ID != userGame.ID || games[0].Number != 2 {
+		t.Errorf("Not the right games: %v", games)
+	}
+}
+
+func TestJoinGameAsShouldRejectASecondSeatForTheUser(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGameAs(g.ID, "azerty", "alice")
+	//When
+	err := gamecontroller.JoinGameAs(g.ID, "qsdfgh", "alice")
+	//Then
+	if err == nil {
+		t.Error("The user should not take a second seat")
+	}
+}
+
+func TestResumeGameShouldGiveBackTheSeatOfTheUser(t *testing.T) {
+	//Given
+	setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGameAs(g.ID, "azerty", "alice")
+	//When
+	seat, err := gamecontroller.ResumeGame(g.ID, "alice")
+	_, errOther := gamecontroller.ResumeGame(g.ID, "bob")
+	//Then
+	if err != nil || seat.Number != 1 {
+		t.Errorf("Not the right seat: %v %v", seat, err)
+	}
+	if errOther == nil {
+		t.Error("A user without a seat should not resume the game")
+	}
+}
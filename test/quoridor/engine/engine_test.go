@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"quoridor/engine"
+	"quoridor/game"
+	"quoridor/openings"
+)
+
+func newEngineProcess(t *testing.T, script string) *engine.Process {
+	p, err := engine.StartProcess([]string{"sh", "-c", script})
+	if err != nil {
+		t.Fatalf("The engine should start: %s", err.Error())
+	}
+	return p
+}
+
+const handshake = `read line; echo "id name fake"; echo quoridorok; `
+
+func TestGreedyShouldTakeTheWinningMove(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	for _, position := range []game.Position{{1, 2}, {4, 1}, {2, 2}, {4, 0}, {3, 2}, {3, 0}} {
+		var err error
+		if g, err = g.MovePawn(position); err != nil {
+			t.Fatalf("The pawn should move: %s", err.Error())
+		}
+	}
+	//When
+	action, err := engine.Greedy{}.BestAction(g, time.Second)
+	//Then
+	if err != nil || action.Type != game.MOVE_ACTION || action.Position.Column != 4 {
+		t.Errorf("The pawn should reach its goal line: %v %v", action.Notation(), err)
+	}
+}
+
+func TestGreedyShouldPlayTheMoveOfItsBook(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3}
+	fence := game.NewFenceAction(game.Fence{game.Position{1, 1}, true})
+	positions, _ := game.Replay(conf, "id", []game.PlayedAction{{1, fence, nil}})
+	book := openings.NewBook(openings.Plies)
+	for i := 0; i < openings.MinGames; i++ {
+		book.Add(positions, 1)
+	}
+	//When
+	action, err := engine.Greedy{book}.BestAction(positions[0], time.Second)
+	//Then
+	if err != nil || action.Notation() != fence.Notation() {
+		t.Errorf("The move of the book should be played: %v %v", action.Notation(), err)
+	}
+}
+
+func TestRandomShouldPlayALegalAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	//When
+	action, err := engine.NewRandom(1).BestAction(g, time.Second)
+	//Then
+	if _, errApply := g.Apply(action); err != nil || errApply != nil {
+		t.Errorf("The action should be legal: %v %v", action, errApply)
+	}
+}
+
+func TestEncodePositionShouldDescribeTheWholeGame(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.AddFence(game.Fence{game.Position{1, 1}, true})
+	//When
+	position := engine.EncodePosition(g)
+	//Then
+	expected := "position size 5 variant standard jump official turn 2 actions 0 pawn a3 east 9 pawn e3 west 10 fence b2h"
+	if position != expected {
+		t.Errorf("Not the right position: %v", position)
+	}
+}
+
+func TestProcessShouldPlayTheBestMoveOfTheEngine(t *testing.T) {
+	//Given
+	p := newEngineProcess(t, handshake+`while read line; do case "$line" in go*) echo "info depth 1"; echo "bestmove b3";; quit) exit 0;; esac; done`)
+	defer p.Close()
+	g, _ := game.NewGame(5)
+	//When
+	action, err := p.BestAction(g, 100*time.Millisecond)
+	//Then
+	if err != nil || action.Notation() != "b3" || p.Name() != "fake" {
+		t.Errorf("Not the move of the engine: %v %v %v", action.Notation(), p.Name(), err)
+	}
+}
+
+func TestProcessShouldDetectACrash(t *testing.T) {
+	//Given
+	p := newEngineProcess(t, handshake+`read line; exit 1`)
+	defer p.Close()
+	g, _ := game.NewGame(5)
+	//When
+	_, err := p.BestAction(g, 100*time.Millisecond)
+	//Then
+	if err != engine.ErrCrashed {
+		t.Errorf("The crash should be detected: %v", err)
+	}
+}
+
+func TestProcessShouldTimeOut(t *testing.T) {
+	//Given
+	p := newEngineProcess(t, handshake+`while read line; do :; done`)
+	defer p.Close()
+	g, _ := game.NewGame(5)
+	//When
+	_, err := p.BestAction(g, 10*time.Millisecond)
+	//Then
+	if err != engine.ErrTimeout {
+		t.Errorf("The engine should time out: %v", err)
+	}
+}
+
+func TestNewFactoryShouldRejectAnUnknownEngine(t *testing.T) {
+	//Given
+	//When
+	_, err := engine.NewFactory("deep-blue")
+	//Then
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("The engine should not exist: %v", err)
+	}
+}
+
+func TestDecodePositionShouldReadTheEncodedGame(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.AddFence(game.Fence{game.Position{1, 1}, true})
+	g, _ = g.MovePawn(game.Position{3, 2})
+	//When
+	decoded, err := engine.DecodePosition(engine.EncodePosition(g))
+	//Then
+	if err != nil {
+		t.Fatalf("The position should be decoded: %s", err.Error())
+	}
+	if engine.EncodePosition(decoded) != engine.EncodePosition(g) {
+		t.Errorf("Not the same position: %v", engine.EncodePosition(decoded))
+	}
+}
+
+func TestServeShouldAnswerTheCommands(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	commands := strings.Join([]string{engine.HelloCommand, engine.EncodePosition(g), engine.GoCommand + " 100", engine.QuitCommand}, "\n")
+	var out strings.Builder
+	//When
+	err := engine.Serve(engine.Greedy{}, strings.NewReader(commands), &out)
+	//Then
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if err != nil || len(lines) != 3 || lines[1] != engine.HelloReply || !strings.HasPrefix(lines[2], engine.BestMovePrefix) {
+		t.Errorf("Not the right answers: %v %v", lines, err)
+	}
+}
+
+func TestProcessShouldSkipTheBestMoveOfATimedOutSearch(t *testing.T) {
+	//Given
+	p := newEngineProcess(t, handshake+`n=0; while read line; do case "$line" in go*) n=$((n+1)); if [ $n = 1 ]; then sleep 1; echo "bestmove b3"; else echo "bestmove c3"; fi;; quit) exit 0;; esac; done`)
+	defer p.Close()
+	g, _ := game.NewGame(5)
+	p.BestAction(g, 10*time.Millisecond)
+	//When
+	action, err := p.BestAction(g, time.Second)
+	//Then
+	if err != nil || action.Notation() != "c3" {
+		t.Errorf("The late move should be skipped: %v %v", action.Notation(), err)
+	}
+}
+
+func TestServeShouldAnswerABestMoveWithoutPosition(t *testing.T) {
+	//Given
+	commands := strings.Join([]string{engine.GoCommand + " 100", engine.QuitCommand}, "\n")
+	var out strings.Builder
+	//When
+	err := engine.Serve(engine.Greedy{}, strings.NewReader(commands), &out)
+	//Then
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if err != nil || lines[len(lines)-1] != engine.BestMovePrefix+engine.NoMove {
+		t.Errorf("The go should get its best move: %v %v", lines, err)
+	}
+}
+
+func TestProcessShouldFailOnNoMove(t *testing.T) {
+	//Given
+	p := newEngineProcess(t, handshake+`n=0; while read line; do case "$line" in go*) n=$((n+1)); if [ $n = 1 ]; then echo "bestmove none"; else echo "bestmove c3"; fi;; quit) exit 0;; esac; done`)
+	defer p.Close()
+	g, _ := game.NewGame(5)
+	_, failure := p.BestAction(g, time.Second)
+	//When
+	action, err := p.BestAction(g, time.Second)
+	//Then
+	if failure != engine.ErrNoMove || err != nil || action.Notation() != "c3" {
+		t.Errorf("The failed search should not delay the next ones: %v %v %v", failure, action.Notation(), err)
+	}
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"encoding/json"
+	"quoridor/game"
+
+	"testing"
+)
+
+func TestApplyShouldMoveThePawn(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	g1, err := g.Apply(game.NewMoveAction(game.Position{1, 1}))
+	//Then
+	if err != nil {
+		t.Errorf("the move should be possible: %s", err.Error())
+		return
+	}
+	if !g1.Pawns[0].Position.Equals(game.Position{1, 1}) {
+		t.Error("The pawn should move east")
+	}
+}
+
+func TestApplyShouldAddTheFenceAndDecreaseTheFencesLeft(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	g1, _ := g.Apply(game.NewFenceAction(game.Fence{game.Position{0, 0}, true}))
+	//Then
+	if len(g1.Fences) != 1 {
+		t.Error("the game should contain a new fence")
+	}
+	if g1.Pawns[0].FencesLeft != game.DefaultNumberOfFences-1 {
+		t.Errorf("The pawn should have one fence less: %v", g1.Pawns[0].FencesLeft)
+	}
+	if g.Pawns[0].FencesLeft != game.DefaultNumberOfFences {
+		t.Error("The previous game should not be modified")
+	}
+}
+
+func TestApplyShouldNotAcceptAnIncompleteAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	_, err := g.Apply(game.Action{Type: game.FENCE_ACTION})
+	//Then
+	if err == nil {
+		t.Error("A fence action without a fence should be rejected")
+		return
+	}
+	if err.Error() != "A fence action requires a fence" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestApplyShouldNotAcceptAnUnknownAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	_, err := g.Apply(game.Action{Type: "jump"})
+	//Then
+	if err == nil {
+		t.Error("An unknown action should be rejected")
+	}
+}
+
+func TestApplyShouldNotAddAFenceWithoutFencesLeft(t *testing.T) {
+	//Given
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3})
+	//When
+	_, err := g.Apply(game.NewFenceAction(game.Fence{game.Position{0, 0}, true}))
+	//Then
+	if err == nil {
+		t.Error("It is not possible to add a fence without fences left")
+		return
+	}
+	if err.Error() != "No more fences to add" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestLegalActionsShouldContainMovesAndFences(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	actions := g.LegalActions()
+	//Then
+	if len(actions) != 11 {
+		t.Errorf("There are 3 moves and 8 fences but get %v actions", len(actions))
+	}
+}
+
+func TestLegalActionsShouldNotContainFencesWithoutFencesLeft(t *testing.T) {
+	//Given
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3})
+	//When
+	actions := g.LegalActions()
+	//Then
+	for _, action := range actions {
+		if action.Type != game.MOVE_ACTION {
+			t.Errorf("Only moves should be possible: %v", action)
+		}
+	}
+}
+
+func TestActionShouldBeDiscriminatedByTypeInJSON(t *testing.T) {
+	//Given
+	var action game.Action
+	//When
+	err := json.Unmarshal([]byte(`{"type": "move", "position": {"column": 1, "row": 1}}`), &action)
+	//Then
+	if err != nil || action.Validate() != nil {
+		t.Errorf("The action should be decoded: %v", err)
+		return
+	}
+	if action.Type != game.MOVE_ACTION || !action.Position.Equals(game.Position{1, 1}) {
+		t.Errorf("Not the right action: %v", action)
+	}
+}
@@ -35,6 +35,16 @@ func TestNewBoardShouldNotBePossibleWithLessThanThree(t *testing.T) {
 	}
 }
 
+func TestNewBoardShouldNotBePossibleWiderThanTheMaximum(t *testing.T) {
+	//Given
+	//When
+	_, err := game.NewBoard(game.MaxBoardSize + 2)
+	//Then
+	if err == nil {
+		t.Error("The size must be at most the maximum")
+	}
+}
+
 func TestIsInBoardShouldReturnTrueIfPositionIsInside(t *testing.T) {
 	//Given
 	board, _ := game.NewBoard(9)
@@ -56,3 +66,29 @@ func TestIsInBoardShouldReturnFalseIfPositionIsOutside(t *testing.T) {
 		t.Error("The position should not be inside the board")
 	}
 }
+
+func TestBlockSquareShouldMakeTheSquareUnavailable(t *testing.T) {
+	//Given
+	board, _ := game.NewBoard(9)
+	//When
+	err := board.BlockSquare(game.Position{4, 4})
+	//Then
+	if err != nil {
+		t.Errorf("block a square should not raise an exception: %v", err.Error())
+		return
+	}
+	if board.IsAvailable(game.Position{4, 4}) {
+		t.Error("The blocked square should not be available")
+	}
+}
+
+func TestBlockSquareShouldNotBePossibleOutsideTheBoard(t *testing.T) {
+	//Given
+	board, _ := game.NewBoard(9)
+	//When
+	err := board.BlockSquare(game.Position{9, 4})
+	//Then
+	if err == nil {
+		t.Error("It is not possible to block a square outside of the board")
+	}
+}
@@ -1,8 +1,10 @@
 package game
 
 import (
+	"quoridor/exception"
 	"quoridor/game"
 
+	"strings"
 	"testing"
 )
 
@@ -369,7 +371,7 @@ func TestJumpPawn(t *testing.T) {
 
 func TestJumpLeft(t *testing.T) {
 	//Given
-	g, _ := game.NewGame(3)
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, JumpRule: game.EDGE_AS_WALL_JUMP})
 	g1, _ := g.MovePawn(game.Position{1, 1})                    // Move Pawn 1
 	g2, _ := g1.AddFence(game.Fence{game.Position{0, 0}, true}) // Add Fence Pawn 2
 	//When
@@ -382,7 +384,7 @@ func TestJumpLeft(t *testing.T) {
 
 func TestJumpLeftImpossibleFence(t *testing.T) {
 	//Given
-	g, _ := game.NewGame(3)
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, JumpRule: game.EDGE_AS_WALL_JUMP})
 	g1, _ := g.MovePawn(game.Position{1, 1})                    // Move Pawn 1
 	g2, _ := g1.AddFence(game.Fence{game.Position{1, 0}, true}) // Add Fence Pawn 2
 	//When
@@ -399,7 +401,7 @@ func TestJumpLeftImpossibleFence(t *testing.T) {
 
 func TestJumpRight(t *testing.T) {
 	//Given
-	g, _ := game.NewGame(3)
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, JumpRule: game.EDGE_AS_WALL_JUMP})
 	g1, _ := g.MovePawn(game.Position{1, 1})                    // Move Pawn 1
 	g2, _ := g1.AddFence(game.Fence{game.Position{0, 0}, true}) // Add Fence Pawn 2
 	//When
@@ -412,7 +414,7 @@ func TestJumpRight(t *testing.T) {
 
 func TestJumpRightImpossibleRight(t *testing.T) {
 	//Given
-	g, _ := game.NewGame(3)
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, JumpRule: game.EDGE_AS_WALL_JUMP})
 	g1, _ := g.MovePawn(game.Position{1, 1})                    // Move Pawn 1
 	g2, _ := g1.AddFence(game.Fence{game.Position{1, 1}, true}) // Add Fence Pawn 2
 	//When
@@ -439,7 +441,7 @@ func TestGetPossibleMoves(t *testing.T) {
 
 func TestGetPossibleMovesWithFenceAndJump(t *testing.T) {
 	//Given
-	g, _ := game.NewGame(3)
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, JumpRule: game.EDGE_AS_WALL_JUMP})
 	g1, _ := g.MovePawn(game.Position{1, 1})                    // Move Pawn 1
 	g2, _ := g1.AddFence(game.Fence{game.Position{1, 1}, true}) // Add Fence Pawn 2
 	//When
@@ -447,3 +449,113 @@ func TestGetPossibleMovesWithFenceAndJump(t *testing.T) {
 	//Then
 	checkMoves(t, moves, game.Positions{game.Position{1, 0}, game.Position{2, 0}, game.Position{0, 1}})
 }
+
+func TestNewGameFromConfigurationShouldBlockSquares(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, BlockedSquares: []game.Position{game.Position{0, 0}}}
+	//When
+	g, err := game.NewGameFromConfiguration(conf)
+	//Then
+	if err != nil {
+		t.Errorf("the game should be created: %s", err.Error())
+		return
+	}
+	if g.Board.IsAvailable(game.Position{0, 0}) {
+		t.Error("The square should be blocked")
+	}
+}
+
+func TestNewGameFromConfigurationShouldNotBlockAPawn(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, BlockedSquares: []game.Position{game.Position{0, 1}}}
+	//When
+	_, err := game.NewGameFromConfiguration(conf)
+	//Then
+	if err == nil {
+		t.Error("It is not possible to block the square of a pawn")
+		return
+	}
+	if err.Error() != "A pawn cannot start on a blocked square" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestNewGameFromConfigurationShouldAddWalls(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, Walls: []game.Fence{game.Fence{game.Position{0, 0}, false}}}
+	//When
+	g, _ := game.NewGameFromConfiguration(conf)
+	//Then
+	if len(g.Fences) != 1 {
+		t.Error("the game should contain the wall")
+	}
+}
+
+func TestNewGameFromConfigurationShouldNotCloseTheGoalLine(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, BlockedSquares: []game.Position{
+		game.Position{1, 0}, game.Position{1, 2}}, Walls: []game.Fence{game.Fence{game.Position{0, 0}, false}}}
+	//When
+	_, err := game.NewGameFromConfiguration(conf)
+	//Then
+	if err == nil {
+		t.Error("It should not be possible to close the access to the goal line")
+		return
+	}
+	if err.Error() != "No more access to goal line" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestGetPossibleMovesShouldAvoidBlockedSquares(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, BlockedSquares: []game.Position{game.Position{1, 1}}}
+	g, _ := game.NewGameFromConfiguration(conf)
+	//When
+	moves := g.GetPossibleMoves()
+	//Then
+	checkMoves(t, moves, game.Positions{game.Position{0, 0}, game.Position{0, 2}})
+}
+
+func TestGetTextBoardShouldRenderBlockedSquares(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 3, BlockedSquares: []game.Position{game.Position{1, 1}}}
+	g, _ := game.NewGameFromConfiguration(conf)
+	//When
+	board := g.GetTextBoard()
+	//Then
+	if !strings.Contains(board, "▨") {
+		t.Errorf("The blocked square should be rendered: %s", board)
+	}
+}
+
+func TestAddFenceShouldNotBePossibleOutsideTheBoard(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	_, err := g.AddFence(game.Fence{game.Position{2, 0}, true})
+	//Then
+	if err == nil {
+		t.Error("It is not possible to add a fence outside of the board")
+		return
+	}
+	if !exception.MatchGameError(err, exception.ILLEGAL_FENCE) {
+		t.Errorf("Not the right error type: %v", err)
+	}
+}
+
+func TestReplayShouldGetThePositionAfterEachAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.MovePawn(game.Position{1, 2})
+	g, _ = g.MovePawn(game.Position{3, 2})
+	//When
+	positions, err := game.Replay(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 10}, g.ID, g.History)
+	//Then
+	if err != nil || len(positions) != 3 {
+		t.Fatalf("Not the right positions: %v %v", positions, err)
+	}
+	if positions[0].Pawns[0].Position != (game.Position{0, 2}) || positions[1].Pawns[0].Position != (game.Position{1, 2}) || positions[2].Pawns[1].Position != (game.Position{3, 2}) || positions[2].ID != g.ID {
+		t.Errorf("Not the right positions: %v", positions)
+	}
+}
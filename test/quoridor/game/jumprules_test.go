@@ -0,0 +1,122 @@
+package game
+
+import (
+	"quoridor/game"
+
+	"testing"
+)
+
+type jumpCase struct {
+	name           string
+	rule           game.JumpRule
+	pawn           game.Position
+	opponent       game.Position
+	fences         []game.Fence
+	blockedSquares []game.Position
+	expected       game.Positions
+}
+
+func buildJumpGame(c jumpCase) game.Game {
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 5, JumpRule: c.rule})
+	g.Pawns[0].Position = c.pawn
+	g.Pawns[1].Position = c.opponent
+	g.Fences = append(g.Fences, c.fences...)
+	for _, square := range c.blockedSquares {
+		g.Board.BlockSquare(square)
+	}
+	return g
+}
+
+func TestJumpRules(t *testing.T) {
+	cases := []jumpCase{
+		// Opponent on the east, in the middle of the board
+		{"east free behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2}, nil, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {4, 2}}},
+		{"east free behind edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{2, 2}, game.Position{3, 2}, nil, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {4, 2}}},
+		{"east free behind no diagonal", game.NO_DIAGONAL_JUMP, game.Position{2, 2}, game.Position{3, 2}, nil, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {4, 2}}},
+		{"east fence behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 1}, {3, 3}}},
+		{"east fence behind edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 1}, {3, 3}}},
+		{"east fence behind no diagonal", game.NO_DIAGONAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}}},
+		{"east fence behind and on the left side", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 2}, false}, {game.Position{3, 1}, true}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 3}}},
+		{"east fence behind and on the right side", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}, {game.Position{3, 2}, true}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 1}}},
+		{"east fence behind and on both sides", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}, {game.Position{2, 1}, true}, {game.Position{3, 2}, true}}, nil,
+			game.Positions{{2, 3}, {1, 2}}},
+		{"east fence between the pawns", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{2, 1}, false}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}}},
+		{"east fence between the pawns edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{2, 1}, false}}, nil,
+			game.Positions{{2, 1}, {2, 3}, {1, 2}}},
+		{"east blocked square behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			nil, []game.Position{{4, 2}},
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 1}, {3, 3}}},
+		{"east blocked square behind no diagonal", game.NO_DIAGONAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			nil, []game.Position{{4, 2}},
+			game.Positions{{2, 1}, {2, 3}, {1, 2}}},
+		{"east blocked square on a side", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{3, 2},
+			[]game.Fence{{game.Position{3, 1}, false}}, []game.Position{{3, 1}},
+			game.Positions{{2, 1}, {2, 3}, {1, 2}, {3, 3}}},
+		// Opponent on the east edge of the board
+		{"east edge behind", game.OFFICIAL_JUMP, game.Position{3, 2}, game.Position{4, 2}, nil, nil,
+			game.Positions{{3, 1}, {3, 3}, {2, 2}}},
+		{"east edge behind edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{3, 2}, game.Position{4, 2}, nil, nil,
+			game.Positions{{3, 1}, {3, 3}, {2, 2}, {4, 1}, {4, 3}}},
+		{"east edge behind no diagonal", game.NO_DIAGONAL_JUMP, game.Position{3, 2}, game.Position{4, 2}, nil, nil,
+			game.Positions{{3, 1}, {3, 3}, {2, 2}}},
+		{"east edge behind and fence on a side edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{3, 2}, game.Position{4, 2},
+			[]game.Fence{{game.Position{3, 1}, true}}, nil,
+			game.Positions{{3, 3}, {2, 2}, {4, 3}}},
+		// Opponent in a corner
+		{"north corner", game.OFFICIAL_JUMP, game.Position{0, 1}, game.Position{0, 0}, nil, nil,
+			game.Positions{{1, 1}, {0, 2}}},
+		{"north corner edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{0, 1}, game.Position{0, 0}, nil, nil,
+			game.Positions{{1, 1}, {0, 2}, {1, 0}}},
+		// Opponent on the other directions
+		{"north free behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{2, 1}, nil, nil,
+			game.Positions{{3, 2}, {2, 3}, {1, 2}, {2, 0}}},
+		{"north fence behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{2, 1},
+			[]game.Fence{{game.Position{2, 0}, true}}, nil,
+			game.Positions{{3, 2}, {2, 3}, {1, 2}, {1, 1}, {3, 1}}},
+		{"north fence behind and on the left side", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{2, 1},
+			[]game.Fence{{game.Position{2, 0}, true}, {game.Position{1, 0}, false}}, nil,
+			game.Positions{{3, 2}, {2, 3}, {1, 2}, {3, 1}}},
+		{"south fence behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{2, 3},
+			[]game.Fence{{game.Position{2, 3}, true}}, nil,
+			game.Positions{{2, 1}, {3, 2}, {1, 2}, {1, 3}, {3, 3}}},
+		{"south edge behind", game.OFFICIAL_JUMP, game.Position{2, 3}, game.Position{2, 4}, nil, nil,
+			game.Positions{{2, 2}, {3, 3}, {1, 3}}},
+		{"south edge behind edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{2, 3}, game.Position{2, 4}, nil, nil,
+			game.Positions{{2, 2}, {3, 3}, {1, 3}, {1, 4}, {3, 4}}},
+		{"west fence behind", game.OFFICIAL_JUMP, game.Position{2, 2}, game.Position{1, 2},
+			[]game.Fence{{game.Position{0, 2}, false}}, nil,
+			game.Positions{{2, 1}, {3, 2}, {2, 3}, {1, 1}, {1, 3}}},
+		{"west fence behind no diagonal", game.NO_DIAGONAL_JUMP, game.Position{2, 2}, game.Position{1, 2},
+			[]game.Fence{{game.Position{0, 2}, false}}, nil,
+			game.Positions{{2, 1}, {3, 2}, {2, 3}}},
+		{"west edge behind edge as wall", game.EDGE_AS_WALL_JUMP, game.Position{1, 2}, game.Position{0, 2}, nil, nil,
+			game.Positions{{1, 1}, {2, 2}, {1, 3}, {0, 1}, {0, 3}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			//Given
+			g := buildJumpGame(c)
+			//When
+			moves := g.GetPossibleMoves()
+			//Then
+			checkMoves(t, moves, c.expected)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+
+	"quoridor/game"
+)
+
+func TestActionNotationShouldUseTheColumnLetterAndTheRowNumber(t *testing.T) {
+	//Given
+	move := game.NewMoveAction(game.Position{4, 2})
+	fence := game.NewFenceAction(game.Fence{game.Position{0, 7}, false})
+	//When
+	moveNotation, fenceNotation := move.Notation(), fence.Notation()
+	//Then
+	if moveNotation != "e3" || fenceNotation != "a8v" {
+		t.Errorf("Not the right notations: %v %v", moveNotation, fenceNotation)
+	}
+}
+
+func TestParseActionShouldReadMovesAndFences(t *testing.T) {
+	//Given
+	//When
+	move, errMove := game.ParseAction("e3")
+	fence, errFence := game.ParseAction("c12h")
+	//Then
+	if errMove != nil || move.Type != game.MOVE_ACTION || !move.Position.Equals(game.Position{4, 2}) {
+		t.Errorf("Not the right move: %v %v", move, errMove)
+	}
+	if errFence != nil || fence.Type != game.FENCE_ACTION || !fence.Fence.Equals(game.Fence{game.Position{2, 11}, true}) {
+		t.Errorf("Not the right fence: %v %v", fence, errFence)
+	}
+}
+
+func TestNotationShouldBeParsedBackOnTheLargestBoard(t *testing.T) {
+	//Given
+	corner := game.Position{game.MaxBoardSize - 1, game.MaxBoardSize - 1}
+	//When
+	parsed, err := game.ParsePosition(corner.Notation())
+	//Then
+	if err != nil || parsed != corner {
+		t.Errorf("The notation %v should be parsed back: %v %v", corner.Notation(), parsed, err)
+	}
+}
+
+func TestParseActionShouldRejectAnInvalidNotation(t *testing.T) {
+	//Given
+	//When
+	_, err := game.ParseAction("3e")
+	//Then
+	if err == nil {
+		t.Error("The notation should be rejected")
+	}
+}
+
+func TestDistanceToGoalShouldFollowTheShortestPath(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.AddFence(game.Fence{game.Position{0, 2}, false})
+	//When
+	distance := g.DistanceToGoal(1)
+	//Then
+	if distance != 5 {
+		t.Errorf("The pawn should go around the fence: %v", distance)
+	}
+}
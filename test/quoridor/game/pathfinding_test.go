@@ -50,3 +50,35 @@ func TestPathShouldNotFindAPathWithFences(t *testing.T) {
 		t.Error("No more path")
 	}
 }
+
+func TestPathShouldGoAroundBlockedSquares(t *testing.T) {
+    //Given
+    board, _ := game.NewBoard(3)
+    board.BlockSquare(game.Position{1, 1})
+    fences := []game.Fence{}
+    src := game.Position{0, 1}
+    destinations := []game.Position{game.Position{2, 0}, game.Position{2, 1}, game.Position{2, 2}}
+	//When
+	d := game.Path(*board, fences, src, destinations)
+	//Then
+	if d != 3 {
+		t.Errorf("The shortest path should go around the blocked square: %v", d)
+	}
+}
+
+func TestPathShouldNotFindAPathThroughBlockedSquares(t *testing.T) {
+    //Given
+    board, _ := game.NewBoard(3)
+    board.BlockSquare(game.Position{1, 0})
+    board.BlockSquare(game.Position{1, 1})
+    board.BlockSquare(game.Position{1, 2})
+    fences := []game.Fence{}
+    src := game.Position{0, 1}
+    destinations := []game.Position{game.Position{2, 0}, game.Position{2, 1}, game.Position{2, 2}}
+	//When
+	d := game.Path(*board, fences, src, destinations)
+	//Then
+	if d != -1 {
+		t.Error("No more path")
+	}
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"quoridor/game"
+)
+
+func TestRenderShouldDrawTheSameBoardAsTheTextBoardWithoutOptions(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	board := g.Render(game.RenderOptions{})
+	//Then
+	expected := " □   □   □ \n           \n ▲   □   △ \n           \n □   □   □ \n"
+	if board != expected || g.GetTextBoard() != expected {
+		t.Errorf("Not the text board:\n%s", board)
+	}
+}
+
+func TestRenderShouldLabelTheCoordinates(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	//When
+	board := g.Render(game.RenderOptions{Coordinates: true})
+	//Then
+	lines := strings.Split(board, "\n")
+	if lines[0] != "   a   b   c  " || !strings.HasPrefix(lines[1], " 1 □") || !strings.HasPrefix(lines[5], " 3 □") {
+		t.Errorf("The coordinates should be labeled:\n%s", board)
+	}
+}
+
+func TestRenderShouldDrawWithASCIIOnly(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	g, _ = g.AddFence(game.Fence{game.Position{0, 0}, true})
+	//When
+	board := g.Render(game.RenderOptions{LegalMoves: true, FencesLeft: true, ASCII: true})
+	//Then
+	for _, r := range board {
+		if r > 127 {
+			t.Errorf("The board should be ASCII only:\n%s", board)
+			return
+		}
+	}
+	if !strings.Contains(board, " # # # ") || !strings.Contains(board, "*") || !strings.Contains(board, "2: 10 fences left to play") {
+		t.Errorf("Not the right board:\n%s", board)
+	}
+}
+
+func TestRenderShouldShowTheLastMove(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	g, _ = g.MovePawn(game.Position{1, 2})
+	//When
+	board := g.Render(game.RenderOptions{LastMove: true, Color: true})
+	//Then
+	if !strings.Contains(board, "Last move: 1 b3") || !strings.Contains(board, "\x1b[31;7m▲\x1b[0m") {
+		t.Errorf("The last move should be marked:\n%q", board)
+	}
+}
+
+func TestRenderShouldReverseTheSelectedFence(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	selection := game.NewFenceAction(game.Fence{game.Position{0, 0}, false})
+	//When
+	board := g.Render(game.RenderOptions{Color: true, Selection: &selection})
+	//Then
+	lines := strings.Split(board, "\n")
+	if strings.Count(board, "\x1b[7m◼\x1b[0m") != 3 || !strings.Contains(lines[1], "\x1b[7m◼\x1b[0m") {
+		t.Errorf("The selected fence should be reversed:\n%q", board)
+	}
+}
+
+func TestApplyShouldRecordTheHistory(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	//When
+	g, _ = g.MovePawn(game.Position{1, 2})
+	g, _ = g.AddFence(game.Fence{game.Position{0, 0}, true})
+	//Then
+	last, found := g.LastAction()
+	if len(g.History) != 2 || !found || last.Number != 2 || last.Action.Notation() != "a1h" || last.From != nil {
+		t.Errorf("Not the right history: %v", g.History)
+	}
+	if g.History[0].From == nil || *g.History[0].From != (game.Position{0, 2}) {
+		t.Errorf("Not the right history: %v", g.History)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"quoridor/game"
+
+	"testing"
+)
+
+func TestGetRulesetShouldReturnStandardByDefault(t *testing.T) {
+	//Given
+	//When
+	ruleset, err := game.GetRuleset("")
+	//Then
+	if err != nil {
+		t.Errorf("the default ruleset should exist: %s", err.Error())
+		return
+	}
+	if _, ok := ruleset.(game.Standard); !ok {
+		t.Errorf("the default ruleset should be the standard one: %v", ruleset)
+	}
+}
+
+func TestGetRulesetShouldRaiseAnExceptionWithAnUnknownVariant(t *testing.T) {
+	//Given
+	//When
+	_, err := game.GetRuleset("chess")
+	//Then
+	if err == nil {
+		t.Error("the variant does not exist, an error should be raised")
+		return
+	}
+	if err.Error() != "The variant chess does not exist" {
+		t.Errorf("Not the right error: %s", err.Error())
+	}
+}
+
+func TestKidsShouldUseASmallerBoardByDefault(t *testing.T) {
+	//Given
+	conf := game.Configuration{Variant: game.KIDS}
+	//When
+	g, err := game.NewGameFromConfiguration(conf)
+	//Then
+	if err != nil {
+		t.Errorf("the game should be created: %s", err.Error())
+		return
+	}
+	if g.Board.BoardSize != game.KidsBoardSize {
+		t.Errorf("The board size should be %v: %v", game.KidsBoardSize, g.Board.BoardSize)
+	}
+}
+
+func TestKidsShouldNotAcceptTheStandardBoard(t *testing.T) {
+	//Given
+	conf := game.Configuration{BoardSize: 9, Variant: game.KIDS}
+	//When
+	_, err := game.NewGameFromConfiguration(conf)
+	//Then
+	if err == nil {
+		t.Error("The board is too big for Quoridor Kids")
+	}
+}
+
+func TestKidsShouldLimitTheNumberOfFences(t *testing.T) {
+	//Given
+	ruleset, _ := game.GetRuleset(game.KIDS)
+	//When
+	conf, _ := ruleset.Configure(game.Configuration{})
+	//Then
+	if conf.NumberOfFencesPerPawnPlayer != game.KidsNumberOfFences {
+		t.Errorf("The number of fences should be %v: %v", game.KidsNumberOfFences, conf.NumberOfFencesPerPawnPlayer)
+	}
+}
+
+func TestMoveOrFenceTwiceShouldKeepTheTurnAfterTheFirstAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, Variant: game.MOVE_OR_FENCE_TWICE})
+	//When
+	g1, _ := g.MovePawn(game.Position{1, 2})
+	//Then
+	if g1.PawnTurn != 1 {
+		t.Errorf("The first pawn should play again: %v", g1.PawnTurn)
+	}
+}
+
+func TestMoveOrFenceTwiceShouldHandOverTheTurnAfterTheSecondAction(t *testing.T) {
+	//Given
+	g, _ := game.NewGameFromConfiguration(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: game.DefaultNumberOfFences, Variant: game.MOVE_OR_FENCE_TWICE})
+	g1, _ := g.MovePawn(game.Position{1, 2})
+	//When
+	g2, _ := g1.AddFence(game.Fence{game.Position{0, 0}, true})
+	//Then
+	if g2.PawnTurn != 2 {
+		t.Errorf("The second pawn should play: %v", g2.PawnTurn)
+	}
+	if g2.TurnActions != 0 {
+		t.Errorf("The actions of the turn should be reset: %v", g2.TurnActions)
+	}
+}
+
+func TestGetPossibleFencesShouldNotCloseTheGoalLine(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(3)
+	g1, _ := g.AddFence(game.Fence{game.Position{0, 0}, false})
+	//When
+	fences := g1.GetPossibleFences()
+	//Then
+	if fences.IndexOf(game.Fence{game.Position{0, 1}, true}) != -1 || fences.IndexOf(game.Fence{game.Position{1, 1}, true}) != -1 {
+		t.Errorf("The fences closing the goal line should not be possible: %v", fences)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"testing"
+
+	"quoridor/exception"
+	"quoridor/game"
+)
+
+func newRace(t *testing.T, boardSize int, first game.Position, second game.Position) game.Game {
+	g, err := game.NewGameFromConfiguration(game.Configuration{BoardSize: boardSize})
+	if err != nil {
+		t.Fatalf("the game should be created: %s", err.Error())
+	}
+	g.Pawns[0] = game.Pawn{first, game.EAST, 0}
+	g.Pawns[1] = game.Pawn{second, game.WEST, 0}
+	return g
+}
+
+func TestSolveShouldRejectAGameWithFencesLeft(t *testing.T) {
+	//Given
+	g, _ := game.NewGame(5)
+	//When
+	_, err := g.Solve()
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The game should not be solved: %v", err)
+	}
+}
+
+func TestSolveShouldWinTheRaceTheShortestWay(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{0, 0}, game.Position{4, 4})
+	//When
+	solution, err := g.Solve()
+	//Then
+	if err != nil || solution.Winner != 1 || solution.Plies != 7 || solution.Action == nil || *solution.Action.Position != (game.Position{1, 0}) {
+		t.Errorf("The first pawn should win in 7 plies: %v %v", solution, err)
+	}
+}
+
+func TestSolveShouldLoseTheRaceTheLongestWay(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{0, 0}, game.Position{1, 4})
+	//When
+	solution, err := g.Solve()
+	//Then
+	if err != nil || solution.Winner != 2 || solution.Plies != 2 || solution.Action == nil {
+		t.Errorf("The second pawn should win in 2 plies: %v %v", solution, err)
+	}
+}
+
+func TestSolveShouldTakeTheJumpsIntoAccount(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{2, 2}, game.Position{3, 2})
+	//When
+	solution, err := g.Solve()
+	//Then
+	if err != nil || solution.Winner != 1 || solution.Plies != 1 || *solution.Action.Position != (game.Position{4, 2}) {
+		t.Errorf("The first pawn should jump to win: %v %v", solution, err)
+	}
+}
+
+func TestSolveShouldFindTheEndlessBlocking(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{2, 2}, game.Position{3, 2})
+	g.PawnTurn = 2
+	//When
+	solution, err := g.Solve()
+	//Then
+	if err != nil || solution.Winner != 0 || solution.Action == nil {
+		t.Errorf("Each pawn should keep the other one from its goal line: %v %v", solution, err)
+	}
+}
+
+func TestSolveShouldGiveTheWinnerOfAnOverGame(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{0, 0}, game.Position{1, 4})
+	g, _ = g.Resign(2)
+	//When
+	solution, err := g.Solve()
+	//Then
+	if err != nil || solution.Winner != 1 || solution.Plies != 0 || solution.Action != nil {
+		t.Errorf("The game should be over: %v %v", solution, err)
+	}
+}
+
+func TestSolveShouldRejectTheBoardsTooLargeToSolve(t *testing.T) {
+	//Given
+	g := newRace(t, game.MaxSolvedBoardSize+2, game.Position{0, 0}, game.Position{10, 10})
+	//When
+	_, err := g.Solve()
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The board should be too large to solve: %v", err)
+	}
+}
+
+func TestSolveShouldGiveTheSolutionOfTheNextPositionsOfTheRace(t *testing.T) {
+	//Given
+	g := newRace(t, 5, game.Position{0, 0}, game.Position{4, 4})
+	first, _ := g.Solve()
+	next, _ := g.Apply(*first.Action)
+	//When
+	solution, err := next.Solve()
+	//Then
+	if err != nil || solution.Winner != first.Winner || solution.Plies != first.Plies-1 || solution.Action == nil {
+		t.Errorf("The race should go on as solved: %v %v", solution, err)
+	}
+}
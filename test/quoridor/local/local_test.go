@@ -0,0 +1,112 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"quoridor/game"
+	"quoridor/local"
+)
+
+var smallBoard = game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3}
+
+func TestUIShouldMoveThePawnToTheSelectedSquare(t *testing.T) {
+	//Given
+	s, _ := local.NewSession(smallBoard, nil)
+	ui := local.NewUI(s, "")
+	//When
+	ui.Handle(local.RIGHT)
+	ui.Handle(local.ENTER)
+	//Then
+	if s.Game.Pawns[0].Position != (game.Position{1, 2}) || s.Game.PawnTurn != 2 {
+		t.Errorf("The pawn should move: %v", s.Game.Pawns[0])
+	}
+}
+
+func TestUIShouldAddTheSelectedFence(t *testing.T) {
+	//Given
+	s, _ := local.NewSession(smallBoard, nil)
+	ui := local.NewUI(s, "")
+	//When
+	ui.Handle(local.FENCE_MODE)
+	ui.Handle(local.ROTATE)
+	ui.Handle(local.UP)
+	ui.Handle(local.ENTER)
+	//Then
+	if len(s.Game.Fences) != 1 || s.Game.Fences[0] != (game.Fence{game.Position{0, 1}, false}) {
+		t.Errorf("The vertical fence should be added: %v", s.Game.Fences)
+	}
+}
+
+func TestUIShouldShowTheErrorOfAnIllegalAction(t *testing.T) {
+	//Given
+	s, _ := local.NewSession(smallBoard, nil)
+	ui := local.NewUI(s, "")
+	//When
+	ui.Handle(local.ENTER)
+	//Then
+	if ui.Message == "" || !strings.Contains(ui.Render(), ui.Message) || s.Game.PawnTurn != 1 {
+		t.Errorf("The error should be shown: %v", ui.Message)
+	}
+}
+
+func TestUIShouldRenderTheCoordinates(t *testing.T) {
+	//Given
+	s, _ := local.NewSession(smallBoard, nil)
+	//When
+	board := local.NewUI(s, "").Render()
+	//Then
+	lines := strings.Split(board, "\n")
+	if !strings.HasPrefix(lines[0], "   a   b   c   d   e") || !strings.HasPrefix(lines[5], " 3 ") {
+		t.Errorf("The coordinates should be shown: %v", board)
+	}
+}
+
+func TestPlayBotsShouldPlayUntilTheHumanTurn(t *testing.T) {
+	//Given
+	s, err := local.NewSession(smallBoard, []string{local.HUMAN, "greedy"})
+	if err != nil {
+		t.Fatalf("The session should start: %s", err.Error())
+	}
+	ui := local.NewUI(s, "")
+	ui.Handle(local.RIGHT)
+	ui.Handle(local.ENTER)
+	//When
+	err = ui.PlayBots()
+	//Then
+	if err != nil || s.Game.PawnTurn != 1 || len(s.Actions) != 2 {
+		t.Errorf("The bot should play: %v %v", s.Actions, err)
+	}
+}
+
+func TestNewSessionShouldRejectAnUnknownPlayer(t *testing.T) {
+	//When
+	_, err := local.NewSession(smallBoard, []string{"stockfish"})
+	//Then
+	if err == nil {
+		t.Error("The player should be rejected")
+	}
+}
+
+func TestLoadShouldReplayTheSavedGame(t *testing.T) {
+	//Given
+	directory, _ := ioutil.TempDir("", "quoridor")
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "game.json")
+	s, _ := local.NewSession(smallBoard, []string{local.HUMAN, "random"})
+	s.Play(game.NewMoveAction(game.Position{1, 2}))
+	s.Play(game.NewFenceAction(game.Fence{game.Position{1, 1}, true}))
+	s.Save(path)
+	//When
+	loaded, err := local.Load(path)
+	//Then
+	if err != nil {
+		t.Fatalf("The game should be loaded: %s", err.Error())
+	}
+	if loaded.Game.PawnTurn != 1 || len(loaded.Game.Fences) != 1 || loaded.Game.Pawns[0].Position != (game.Position{1, 2}) || loaded.Players[1] != "random" {
+		t.Errorf("Not the saved game: %v", loaded)
+	}
+}
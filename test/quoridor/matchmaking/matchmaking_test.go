@@ -0,0 +1,151 @@
+package matchmaking
+
+import (
+	"testing"
+	"time"
+
+	"quoridor/controller"
+	"quoridor/matchmaking"
+	"quoridor/storage"
+)
+
+func startMatchmaker() (*matchmaking.Matchmaker, chan struct{}) {
+	storage.Init()
+	matchmaker := matchmaking.NewMatchmaker()
+	stop := make(chan struct{})
+	go matchmaker.Run(stop)
+	return matchmaker, stop
+}
+
+func TestMatchmakerShouldPairTwoPlayers(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	first, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 5, "", ""})
+	second, _ := matchmaker.Enqueue(matchmaking.Preferences{"bob", 0, "", ""})
+	//When
+	firstMatch, firstMatched, _ := matchmaker.Wait(first, time.Second)
+	secondMatch, secondMatched, _ := matchmaker.Wait(second, time.Second)
+	//Then
+	if !firstMatched || !secondMatched || firstMatch.GameID != secondMatch.GameID {
+		t.Errorf("The players should be in the same game: %v %v", firstMatch, secondMatch)
+		return
+	}
+	if firstMatch.Number != 1 || secondMatch.Number != 2 {
+		t.Errorf("The first player in the queue should have the first seat: %v %v", firstMatch, secondMatch)
+	}
+	g, _ := gamecontroller.GetGame(firstMatch.GameID)
+	if g.Board.BoardSize != 5 {
+		t.Errorf("The preferred board size should be used: %v", g.Board.BoardSize)
+	}
+	status, _ := gamecontroller.GetStatus(firstMatch.GameID)
+	if status != gamecontroller.PLAYING {
+		t.Errorf("The game should be playing: %v", status)
+	}
+}
+
+func TestMatchmakerShouldNotPairIncompatiblePlayers(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	first, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 5, "", ""})
+	matchmaker.Enqueue(matchmaking.Preferences{"bob", 7, "", ""})
+	matchmaker.Enqueue(matchmaking.Preferences{"carol", 9, "", ""})
+	//When
+	_, matched, err := matchmaker.Wait(first, 50*time.Millisecond)
+	//Then
+	if err != nil || matched {
+		t.Error("The player should still be searching")
+	}
+}
+
+func TestMatchmakerShouldRejectAnInvalidBoardSize(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	//When
+	_, err := matchmaker.Enqueue(matchmaking.Preferences{"alice", 8, "", ""})
+	//Then
+	if err == nil {
+		t.Error("The board size should be rejected")
+	}
+}
+
+func TestMatchmakerShouldCancelATicket(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	first, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 0, "", ""})
+	//When
+	err := matchmaker.Cancel(first)
+	matchmaker.Enqueue(matchmaking.Preferences{"bob", 0, "", ""})
+	//Then
+	if err != nil {
+		t.Errorf("The ticket should be cancelled: %s", err.Error())
+	}
+	if _, _, err := matchmaker.Wait(first, time.Millisecond); err == nil {
+		t.Error("The cancelled ticket should not exist anymore")
+	}
+}
+
+func TestMatchmakerShouldPairTheSameTimeControls(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	first, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 5, "5+0", ""})
+	second, _ := matchmaker.Enqueue(matchmaking.Preferences{"bob", 5, "3+2", ""})
+	third, _ := matchmaker.Enqueue(matchmaking.Preferences{"carol", 5, "5+0", ""})
+	//When
+	firstMatch, firstMatched, _ := matchmaker.Wait(first, time.Second)
+	thirdMatch, thirdMatched, _ := matchmaker.Wait(third, time.Second)
+	_, secondMatched, _ := matchmaker.Wait(second, 50*time.Millisecond)
+	//Then
+	if !firstMatched || !thirdMatched || firstMatch.GameID != thirdMatch.GameID || secondMatched {
+		t.Errorf("Only the players with the same time control should be paired: %v %v %v", firstMatch, thirdMatch, secondMatched)
+	}
+}
+
+func TestMatchmakerShouldSeatTheUsers(t *testing.T) {
+	//Given
+	matchmaker, stop := startMatchmaker()
+	defer close(stop)
+	first, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 5, "", "alice-id"})
+	matchmaker.Enqueue(matchmaking.Preferences{"alice again", 5, "", "alice-id"})
+	third, _ := matchmaker.Enqueue(matchmaking.Preferences{"bob", 5, "", "bob-id"})
+	//When
+	match, matched, _ := matchmaker.Wait(first, time.Second)
+	matchmaker.Wait(third, time.Second)
+	//Then
+	if !matched {
+		t.Fatal("The player should be matched")
+	}
+	seat, err := gamecontroller.ResumeGame(match.GameID, "bob-id")
+	if err != nil || seat.Number != 2 {
+		t.Errorf("The user should be seated: %v %v", seat, err)
+	}
+	if _, _, err := matchmaker.Wait(first, time.Millisecond); err == nil {
+		t.Error("The matched ticket should be removed once given")
+	}
+}
+
+func TestMatchmakerShouldNotPairAnExpiredTicket(t *testing.T) {
+	//Given
+	storage.Init()
+	matchmaker := matchmaking.NewMatchmaker()
+	matchmaker.TTL = 10 * time.Millisecond
+	stop := make(chan struct{})
+	defer close(stop)
+	go matchmaker.Run(stop)
+	ghost, _ := matchmaker.Enqueue(matchmaking.Preferences{"ghost", 0, "", ""})
+	time.Sleep(30 * time.Millisecond)
+	//When
+	player, _ := matchmaker.Enqueue(matchmaking.Preferences{"alice", 0, "", ""})
+	_, matched, _ := matchmaker.Wait(player, 50*time.Millisecond)
+	//Then
+	if matched {
+		t.Error("The player should not be paired with the ghost")
+	}
+	if _, _, err := matchmaker.Wait(ghost, time.Millisecond); err == nil {
+		t.Error("The expired ticket should be removed")
+	}
+}
@@ -0,0 +1,118 @@
+package openings
+
+import (
+	"testing"
+
+	"quoridor/game"
+	"quoridor/openings"
+)
+
+func replay(t *testing.T, actions ...game.Action) []game.Game {
+	history := []game.PlayedAction{}
+	for index, action := range actions {
+		history = append(history, game.PlayedAction{index%2 + 1, action, nil})
+	}
+	positions, err := game.Replay(game.Configuration{BoardSize: 9, NumberOfFencesPerPawnPlayer: 10}, "id", history)
+	if err != nil {
+		t.Fatalf("the game should be replayed: %s", err.Error())
+	}
+	return positions
+}
+
+func TestHashShouldBeSharedWithTheMirrorImage(t *testing.T) {
+	//Given
+	north := replay(t, game.NewFenceAction(game.Fence{game.Position{2, 1}, true}))
+	south := replay(t, game.NewFenceAction(game.Fence{game.Position{2, 6}, true}))
+	other := replay(t, game.NewFenceAction(game.Fence{game.Position{2, 2}, true}))
+	//When
+	northHash, northMirrored := openings.Hash(north[1])
+	southHash, southMirrored := openings.Hash(south[1])
+	otherHash, _ := openings.Hash(other[1])
+	//Then
+	if northHash != southHash || northMirrored == southMirrored {
+		t.Errorf("The mirror images should share the hash: %v %v %v %v", northHash, northMirrored, southHash, southMirrored)
+	}
+	if otherHash == northHash {
+		t.Errorf("Another position should have another hash: %v", otherHash)
+	}
+}
+
+func TestAddShouldCountTheOutcomesForThePlayerWhoMoved(t *testing.T) {
+	//Given
+	book := openings.NewBook(openings.Plies)
+	fence := game.NewFenceAction(game.Fence{game.Position{2, 1}, true})
+	//When
+	book.Add(replay(t, fence), 1)
+	book.Add(replay(t, fence), 2)
+	book.Add(replay(t, game.NewMoveAction(game.Position{1, 4})), 2)
+	//Then
+	starts := book.Starts()
+	if len(starts) != 1 || starts[0].Games != 3 || len(starts[0].Moves) != 2 {
+		t.Errorf("Not the right start: %v", starts)
+	}
+	move := starts[0].Moves[0]
+	if move.Notation != "c2h" || move.Games != 2 || move.Wins != 1 || move.Losses != 1 || move.Draws != 0 {
+		t.Errorf("Not the right stats: %v", move)
+	}
+	if next, _ := openings.Hash(replay(t, fence)[1]); move.Next != next {
+		t.Errorf("Not the right next position: %v", move.Next)
+	}
+}
+
+func TestAddShouldOnlyKeepTheFirstPlies(t *testing.T) {
+	//Given
+	book := openings.NewBook(1)
+	//When
+	book.Add(replay(t, game.NewMoveAction(game.Position{1, 4}), game.NewMoveAction(game.Position{7, 4})), 1)
+	//Then
+	starts := book.Starts()
+	if len(starts) != 1 {
+		t.Fatalf("Not the right start: %v", starts)
+	}
+	if _, found := book.Get(starts[0].Moves[0].Next); found {
+		t.Error("The second ply should not be in the book")
+	}
+}
+
+func TestLookupShouldMirrorTheActions(t *testing.T) {
+	//Given
+	book := openings.NewBook(openings.Plies)
+	book.Add(replay(t, game.NewFenceAction(game.Fence{game.Position{2, 1}, true}), game.NewMoveAction(game.Position{8, 3})), 1)
+	mirrored := replay(t, game.NewFenceAction(game.Fence{game.Position{2, 6}, true}))
+	//When
+	opening, found := book.Lookup(mirrored[1])
+	//Then
+	if !found || len(opening.Moves) != 1 || opening.Moves[0].Notation != "i6" {
+		t.Errorf("The action should be mirrored: %v %v", opening, found)
+	}
+}
+
+func TestSuggestShouldPlayTheBestMoveOfTheBook(t *testing.T) {
+	//Given
+	book := openings.NewBook(openings.Plies)
+	fence := game.NewFenceAction(game.Fence{game.Position{2, 1}, true})
+	move := game.NewMoveAction(game.Position{1, 4})
+	for i := 0; i < openings.MinGames; i++ {
+		book.Add(replay(t, fence), 1)
+		book.Add(replay(t, move), 2)
+	}
+	book.Add(replay(t, game.NewMoveAction(game.Position{0, 3})), 1)
+	start := replay(t)[0]
+	//When
+	action, found := book.Suggest(start)
+	//Then
+	if !found || action.Notation() != "c2h" {
+		t.Errorf("The winning fence should be suggested: %v %v", action, found)
+	}
+}
+
+func TestSuggestShouldIgnoreAnUnknownPosition(t *testing.T) {
+	//Given
+	book := openings.NewBook(openings.Plies)
+	//When
+	_, found := book.Suggest(replay(t)[0])
+	//Then
+	if found {
+		t.Error("Nothing should be suggested")
+	}
+}
@@ -0,0 +1,35 @@
+package rating
+
+import (
+	"math"
+	"testing"
+
+	"quoridor/rating"
+)
+
+func TestUpdateShouldFollowTheGlicko2Example(t *testing.T) {
+	//Given
+	player := rating.Rating{1500, 200, 0.06}
+	results := []rating.Result{
+		{rating.Rating{1400, 30, 0.06}, 1},
+		{rating.Rating{1550, 100, 0.06}, 0},
+		{rating.Rating{1700, 300, 0.06}, 0},
+	}
+	//When
+	updated := rating.Update(player, results)
+	//Then
+	if math.Abs(updated.Rating-1464.06) > 0.01 || math.Abs(updated.Deviation-151.52) > 0.01 || math.Abs(updated.Volatility-0.05999) > 0.00001 {
+		t.Errorf("Not the rating of the example: %v", updated)
+	}
+}
+
+func TestUpdateShouldIncreaseTheDeviationWithoutGames(t *testing.T) {
+	//Given
+	player := rating.Rating{1500, 50, 0.06}
+	//When
+	updated := rating.Update(player, []rating.Result{})
+	//Then
+	if updated.Rating != 1500 || updated.Deviation <= 50 {
+		t.Errorf("Only the deviation should increase: %v", updated)
+	}
+}
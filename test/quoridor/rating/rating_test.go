@@ -0,0 +1,97 @@
+package rating
+
+import (
+	"testing"
+
+	"quoridor/controller"
+	"quoridor/game"
+	"quoridor/rating"
+	"quoridor/storage"
+	"quoridor/users"
+)
+
+func setUp() (users.User, users.User) {
+	storage.Init()
+	alice, _ := users.Register(users.Credentials{"alice", "correct horse"})
+	bob, _ := users.Register(users.Credentials{"bob", "battery staple"})
+	return alice, bob
+}
+
+func TestRecordShouldUpdateTheRatingsOfBothUsers(t *testing.T) {
+	//Given
+	alice, bob := setUp()
+	//When
+	rating.Record(gamecontroller.GameResult{"azerty", 9, 1, []string{alice.ID, bob.ID}})
+	//Then
+	winner, _ := rating.GetRating(alice.ID, 9)
+	loser, _ := rating.GetRating(bob.ID, 9)
+	if winner.Rating.Rating <= rating.DefaultRating || loser.Rating.Rating >= rating.DefaultRating {
+		t.Errorf("The winner should gain what the loser loses: %v %v", winner.Rating, loser.Rating)
+	}
+	if winner.Games != 1 || len(winner.History) != 1 || winner.History[0].GameID != "azerty" {
+		t.Errorf("The game should be in the history: %v", winner)
+	}
+}
+
+func TestRecordShouldRateEachBoardSizeApart(t *testing.T) {
+	//Given
+	alice, bob := setUp()
+	//When
+	rating.Record(gamecontroller.GameResult{"azerty", 5, 1, []string{alice.ID, bob.ID}})
+	//Then
+	onNine, _ := rating.GetRating(alice.ID, 9)
+	if onNine.Games != 0 || onNine.Rating != rating.NewRating() {
+		t.Errorf("The rating on another board size should not change: %v", onNine)
+	}
+}
+
+func TestRecordShouldIgnoreTheGamesOfAnonymousPlayers(t *testing.T) {
+	//Given
+	alice, _ := setUp()
+	//When
+	rating.Record(gamecontroller.GameResult{"azerty", 9, 1, []string{alice.ID, ""}})
+	//Then
+	if len(rating.Leaderboard(0)) != 0 {
+		t.Error("The game should not be rated")
+	}
+}
+
+func TestRecordShouldIgnoreTheForks(t *testing.T) {
+	//Given
+	alice, bob := setUp()
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	fork, _ := gamecontroller.ForkGame(g.ID, 1, "")
+	//When
+	rating.Record(gamecontroller.GameResult{fork.ID, 5, 1, []string{alice.ID, bob.ID}})
+	//Then
+	if len(rating.Leaderboard(0)) != 0 {
+		t.Error("The fork should not be rated")
+	}
+}
+
+func TestLeaderboardShouldListTheBestFirst(t *testing.T) {
+	//Given
+	alice, bob := setUp()
+	rating.Record(gamecontroller.GameResult{"azerty", 9, 2, []string{alice.ID, bob.ID}})
+	rating.Record(gamecontroller.GameResult{"qsdfgh", 5, 1, []string{alice.ID, bob.ID}})
+	//When
+	leaderboard := rating.Leaderboard(9)
+	//Then
+	if len(leaderboard) != 2 || leaderboard[0].Name != "bob" || leaderboard[0].History != nil {
+		t.Errorf("Not the right leaderboard: %v", leaderboard)
+	}
+}
+
+func TestGetRatingShouldRejectAnUnknownUser(t *testing.T) {
+	//Given
+	setUp()
+	//When
+	_, err := rating.GetRating("azerty", 9)
+	//Then
+	if err == nil {
+		t.Error("The user should not exist")
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"quoridor/exception"
+	"quoridor/server/response"
+)
+
+func decodeError(t *testing.T, recorder *httptest.ResponseRecorder) response.Error {
+	var body response.Error
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Errorf("The error body should be JSON: %s", recorder.Body.String())
+	}
+	return body
+}
+
+func TestSendErrorShouldSendNotFound(t *testing.T) {
+	//Given
+	recorder := httptest.NewRecorder()
+	//When
+	response.SendError(recorder, exception.New(exception.NOT_FOUND, "The game does not exist"))
+	//Then
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("The status should be 404: %v", recorder.Code)
+	}
+	body := decodeError(t, recorder)
+	if body.Code != "not_found" || body.Message != "The game does not exist" {
+		t.Errorf("Not the right error: %v", body)
+	}
+}
+
+func TestSendErrorShouldSendForbidden(t *testing.T) {
+	//Given
+	recorder := httptest.NewRecorder()
+	//When
+	response.SendError(recorder, exception.New(exception.FORBIDDEN, "Forbidden"))
+	//Then
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("The status should be 403: %v", recorder.Code)
+	}
+}
+
+func TestSendErrorShouldSendConflictWhenItIsNotYourTurn(t *testing.T) {
+	//Given
+	recorder := httptest.NewRecorder()
+	//When
+	response.SendError(recorder, exception.New(exception.NOT_YOUR_TURN, "It is not your turn"))
+	//Then
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("The status should be 409: %v", recorder.Code)
+	}
+	if body := decodeError(t, recorder); body.Code != "not_your_turn" {
+		t.Errorf("Not the right code: %v", body.Code)
+	}
+}
+
+func TestSendErrorShouldSendUnprocessableEntityForAnIllegalMove(t *testing.T) {
+	//Given
+	recorder := httptest.NewRecorder()
+	//When
+	response.SendError(recorder, exception.New(exception.ILLEGAL_MOVE, "It is not possible to move to {2 2}"))
+	//Then
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("The status should be 422: %v", recorder.Code)
+	}
+}
+
+func TestSendErrorShouldSendBadRequestForOtherErrors(t *testing.T) {
+	//Given
+	recorder := httptest.NewRecorder()
+	//When
+	response.SendError(recorder, errors.New(`invalid character '"' in "body"`))
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+	body := decodeError(t, recorder)
+	if body.Code != response.BadRequestCode || body.Message != `invalid character '"' in "body"` {
+		t.Errorf("The message should be escaped: %v", body)
+	}
+}
@@ -1,9 +1,365 @@
 package server
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"quoridor/bot"
+	"quoridor/game"
+	"quoridor/matchmaking"
+	"quoridor/server"
+	"quoridor/server/response"
+	"quoridor/storage"
 )
 
+func newRouter(t *testing.T) http.Handler {
+	storage.Init()
+	router, err := server.NewRouter()
+	if err != nil {
+		t.Fatalf("the router should be created: %s", err.Error())
+	}
+	return router
+}
+
+func serve(t *testing.T, method string, url string, body string, header http.Header) *httptest.ResponseRecorder {
+	return serveWith(newRouter(t), method, url, body, header)
+}
+
+func serveWith(router http.Handler, method string, url string, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+	return recorder
+}
+
 func TestWelcome(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/", "", nil)
+	//Then
+	if recorder.Code != http.StatusOK {
+		t.Errorf("The status should be 200: %v", recorder.Code)
+	}
+}
+
+func TestOpenAPIShouldServeTheDocument(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/openapi.json", "", nil)
+	//Then
+	var document map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+		t.Errorf("The document should be JSON: %s", err.Error())
+		return
+	}
+	if !strings.HasPrefix(document["openapi"].(string), "3.") {
+		t.Errorf("The document should be an OpenAPI 3 document: %v", document["openapi"])
+	}
+}
+
+func TestCreateGameShouldUseTheConfigurationFieldNames(t *testing.T) {
+	//Given
+	body := `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`
+	//When
+	recorder := serve(t, "POST", "/games", body, nil)
+	//Then
+	var g game.Game
+	json.Unmarshal(recorder.Body.Bytes(), &g)
+	if g.Board == nil || g.Board.BoardSize != 5 || g.Pawns[0].FencesLeft != 3 {
+		t.Errorf("The configuration should be used: %s", recorder.Body.String())
+	}
+}
+
+func TestCreateGameShouldRejectUnknownFields(t *testing.T) {
+	//Given
+	body := `{"boardSize": 5, "BoardSize": 7}`
+	//When
+	recorder := serve(t, "POST", "/games", body, nil)
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+	var e response.Error
+	json.Unmarshal(recorder.Body.Bytes(), &e)
+	if e.Code != "invalid_request" || e.Message != "body.BoardSize is not allowed" {
+		t.Errorf("Not the right error: %v", e)
+	}
+}
+
+func TestCreateGameShouldRejectAWrongType(t *testing.T) {
+	//Given
+	body := `{"boardSize": "nine"}`
+	//When
+	recorder := serve(t, "POST", "/games", body, nil)
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+}
+
+func TestCreateGameShouldSendUnprocessableEntityForAnEvenBoard(t *testing.T) {
+	//Given
+	body := `{"boardSize": 8}`
+	//When
+	recorder := serve(t, "POST", "/games", body, nil)
+	//Then
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("The status should be 422: %v", recorder.Code)
+	}
+}
+
+func TestMovePawnShouldRequireTheAuthorizationHeader(t *testing.T) {
+	//Given
+	body := `{"column": 1, "row": 4}`
+	//When
+	recorder := serve(t, "PUT", "/games/azerty/move-pawn", body, nil)
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+}
+
+func TestGetGameShouldSendNotFound(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/games/azerty", "", nil)
+	//Then
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("The status should be 404: %v", recorder.Code)
+	}
+}
+
+func TestLobbyShouldListTheWaitingGames(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	serveWith(router, "POST", "/games?creator=alice", `{"boardSize": 5}`, nil)
+	serveWith(router, "POST", "/games", `{"boardSize": 7}`, nil)
+	//When
+	recorder := serveWith(router, "GET", "/games?status=waiting&boardSize=5", "", nil)
+	//Then
+	var page struct {
+		Items []struct {
+			Creator string `json:"creator"`
+			Status  string `json:"status"`
+		} `json:"items"`
+		Total int `json:"total"`
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &page)
+	if page.Total != 1 || page.Items[0].Creator != "alice" || page.Items[0].Status != "waiting" {
+		t.Errorf("Not the right games: %s", recorder.Body.String())
+	}
+}
+
+func TestLobbyShouldRejectAnUnknownStatus(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/games?status=paused", "", nil)
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+}
+
+func TestMatchmakingShouldGiveASeatToBothPlayers(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	matchmaking.Init()
+	var tickets [2]server.Ticket
+	for index := range tickets {
+		recorder := serveWith(router, "POST", "/matchmaking", `{"boardSize": 5}`, nil)
+		json.Unmarshal(recorder.Body.Bytes(), &tickets[index])
+	}
+	//When
+	var matches [2]matchmaking.Match
+	for index, ticket := range tickets {
+		recorder := serveWith(router, "GET", "/matchmaking/"+ticket.ID+"?wait=1", "", nil)
+		if recorder.Code != http.StatusOK {
+			t.Errorf("The status should be 200: %v", recorder.Code)
+		}
+		json.Unmarshal(recorder.Body.Bytes(), &matches[index])
+	}
+	//Then
+	if matches[0].GameID == "" || matches[0].GameID != matches[1].GameID || matches[0].Token == matches[1].Token {
+		t.Errorf("The players should have a seat in the same game: %v", matches)
+	}
+}
+
+func TestUserShouldResumeAGameFromAnotherDevice(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	credentials := `{"name": "alice", "password": "correct horse"}`
+	serveWith(router, "POST", "/v1/users", credentials, nil)
+	var session struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(serveWith(router, "POST", "/v1/sessions", credentials, nil).Body.Bytes(), &session)
+	authorization := http.Header{"Authorization": {"Bearer " + session.Token}}
+	var g server.GameResource
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5}`, nil).Body.Bytes(), &g)
+	var joined server.SeatToken
+	json.Unmarshal(serveWith(router, "POST", "/v1/games/"+g.ID+"/players", "", authorization).Body.Bytes(), &joined)
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+g.ID+"/resume", "", authorization)
+	//Then
+	var resumed server.SeatToken
+	json.Unmarshal(recorder.Body.Bytes(), &resumed)
+	if recorder.Code != http.StatusOK || resumed != joined {
+		t.Errorf("The seat should be resumed: %v %s", recorder.Code, recorder.Body.String())
+	}
+	var history struct {
+		Total int `json:"total"`
+	}
+	json.Unmarshal(serveWith(router, "GET", "/v1/users/me/games", "", authorization).Body.Bytes(), &history)
+	if history.Total != 1 {
+		t.Errorf("The game should be in the history of the user: %v", history.Total)
+	}
+}
+
+func TestCurrentUserShouldRequireASession(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/v1/users/me", "", http.Header{"Authorization": {"Bearer azerty"}})
+	//Then
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("The status should be 401: %v", recorder.Code)
+	}
+}
+
+func TestPlayActionShouldAcceptTheBearerPrefix(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	var g server.GameResource
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5}`, nil).Body.Bytes(), &g)
+	var first server.SeatToken
+	json.Unmarshal(serveWith(router, "POST", "/v1/games/"+g.ID+"/players", "", nil).Body.Bytes(), &first)
+	serveWith(router, "POST", "/v1/games/"+g.ID+"/players", "", nil)
+	header := http.Header{"Authorization": {"Bearer " + first.Token}}
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 2}}`, header)
+	//Then
+	if recorder.Code != http.StatusOK {
+		t.Errorf("The status should be 200: %v %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestPlayActionShouldRejectAnOpaqueToken(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	var g server.GameResource
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5}`, nil).Body.Bytes(), &g)
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 2}}`, http.Header{"Authorization": {"azerty"}})
+	//Then
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("The status should be 401: %v", recorder.Code)
+	}
+}
+
+func TestPlayActionShouldRejectATokenOfAnotherGame(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	var first, second server.GameResource
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5}`, nil).Body.Bytes(), &first)
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5}`, nil).Body.Bytes(), &second)
+	var seat server.SeatToken
+	json.Unmarshal(serveWith(router, "POST", "/v1/games/"+first.ID+"/players", "", nil).Body.Bytes(), &seat)
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+second.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 2}}`, http.Header{"Authorization": {seat.Token}})
+	//Then
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("The status should be 403: %v", recorder.Code)
+	}
+}
+
+func TestRatingShouldSendNotFoundForAnUnknownUser(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/players/azerty/rating", "", nil)
+	//Then
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("The status should be 404: %v", recorder.Code)
+	}
+}
+
+func TestLeaderboardShouldFilterByBoardSize(t *testing.T) {
+	//Given
+	//When
+	recorder := serve(t, "GET", "/v1/leaderboard?boardSize=5", "", nil)
+	//Then
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"total":0`) {
+		t.Errorf("The leaderboard should be empty: %v %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestTournamentShouldSeatTheRegisteredParticipants(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	var created struct {
+		ID             string `json:"id"`
+		OrganizerToken string `json:"organizerToken"`
+	}
+	json.Unmarshal(serveWith(router, "POST", "/v1/tournaments", `{"name": "ladder", "format": "round-robin", "configuration": {"boardSize": 5}}`, nil).Body.Bytes(), &created)
+	var registration struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(serveWith(router, "POST", "/v1/tournaments/"+created.ID+"/participants", `{"name": "alice"}`, nil).Body.Bytes(), &registration)
+	serveWith(router, "POST", "/v1/tournaments/"+created.ID+"/participants", `{"name": "bob"}`, nil)
+	serveWith(router, "POST", "/v1/tournaments/"+created.ID+"/start", "", http.Header{"Authorization": {"Bearer " + created.OrganizerToken}})
+	//When
+	recorder := serveWith(router, "GET", "/v1/tournaments/"+created.ID+"/seat", "", http.Header{"Authorization": {"Bearer " + registration.Token}})
+	//Then
+	var seat server.GameSeat
+	json.Unmarshal(recorder.Body.Bytes(), &seat)
+	if recorder.Code != http.StatusOK || seat.GameID == "" || seat.Token == "" {
+		t.Errorf("The participant should have a seat: %v %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestAddBotShouldSeatTheEngine(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	bot.Configure("")
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`, nil).Body.Bytes(), &created)
+	//When
+	w := serveWith(router, "POST", "/v1/games/"+created.ID+"/bots", `{"engine": "greedy"}`, nil)
+	bot.Stop(created.ID)
+	//Then
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"number":1`) {
+		t.Errorf("The bot should be seated: %v %v", w.Code, w.Body.String())
+	}
+}
+
+func TestAddBotShouldRequireEitherAnEngineOrAURL(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	var created struct {
+		ID string `json:"id"`
+	}
+	json.Unmarshal(serveWith(router, "POST", "/v1/games", `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`, nil).Body.Bytes(), &created)
+	//When
+	w := serveWith(router, "POST", "/v1/games/"+created.ID+"/bots", `{"engine": "greedy", "url": "http://localhost:8080/bot"}`, nil)
+	//Then
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("The request should be rejected: %v %v", w.Code, w.Body.String())
+	}
+}
 
+func TestValidationShouldRejectATooLargeBody(t *testing.T) {
+	//Given
+	body := `{"boardSize": 5, "variant": "` + strings.Repeat("x", server.MaxBodySize) + `"}`
+	//When
+	recorder := serve(t, "POST", "/v1/games", body, nil)
+	//Then
+	if recorder.Code != http.StatusRequestEntityTooLarge || !strings.Contains(recorder.Body.String(), "too_large") {
+		t.Errorf("The body should be too large: %v %s", recorder.Code, recorder.Body.String())
+	}
 }
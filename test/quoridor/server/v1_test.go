@@ -0,0 +1,317 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"quoridor/controller"
+	"quoridor/game"
+	"quoridor/openings"
+	"quoridor/server"
+	"quoridor/server/response"
+)
+
+func createV1Game(t *testing.T, router http.Handler, body string) server.GameResource {
+	recorder := serveWith(router, "POST", "/v1/games", body, nil)
+	var resource server.GameResource
+	json.Unmarshal(recorder.Body.Bytes(), &resource)
+	return resource
+}
+
+func joinV1Game(router http.Handler, gameID string) server.SeatToken {
+	recorder := serveWith(router, "POST", "/v1/games/"+gameID+"/players", "", nil)
+	var seat server.SeatToken
+	json.Unmarshal(recorder.Body.Bytes(), &seat)
+	return seat
+}
+
+func TestV1CreateGameShouldSendCreated(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	//When
+	recorder := serveWith(router, "POST", "/v1/games", `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`, nil)
+	//Then
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("The status should be 201: %v", recorder.Code)
+	}
+	var resource server.GameResource
+	json.Unmarshal(recorder.Body.Bytes(), &resource)
+	if resource.Status != "waiting" || resource.BoardSize != 5 || len(resource.Players) != 2 {
+		t.Errorf("Not the right game: %s", recorder.Body.String())
+	}
+	if resource.Players[0].Goal != "east" || resource.Players[0].FencesLeft != 3 || resource.Players[0].Seated {
+		t.Errorf("Not the right player: %v", resource.Players[0])
+	}
+}
+
+func TestV1JoinGameShouldSeatThePlayers(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	//When
+	first := joinV1Game(router, g.ID)
+	second := joinV1Game(router, g.ID)
+	//Then
+	if first.Number != 1 || second.Number != 2 || first.Token == "" {
+		t.Errorf("Not the right seats: %v %v", first, second)
+	}
+	recorder := serveWith(router, "GET", "/v1/games/"+g.ID, "", nil)
+	var resource server.GameResource
+	json.Unmarshal(recorder.Body.Bytes(), &resource)
+	if resource.Status != "playing" {
+		t.Errorf("The game should be playing: %v", resource.Status)
+	}
+}
+
+func TestV1PlayActionShouldMoveThePawn(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	first := joinV1Game(router, g.ID)
+	joinV1Game(router, g.ID)
+	header := http.Header{"Authorization": []string{first.Token}}
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 4}}`, header)
+	//Then
+	var resource server.GameResource
+	json.Unmarshal(recorder.Body.Bytes(), &resource)
+	if resource.CurrentPlayer != 2 || resource.Players[0].Position.Column != 1 {
+		t.Errorf("The pawn should be moved: %s", recorder.Body.String())
+	}
+}
+
+func TestV1GetLegalActionsShouldBePaginated(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, `{"boardSize": 3, "numberOfFencesPerPlayer": 1}`)
+	//When
+	recorder := serveWith(router, "GET", "/v1/games/"+g.ID+"/legal-actions?page=3&perPage=5", "", nil)
+	//Then
+	var page struct {
+		Items   []interface{} `json:"items"`
+		Page    int           `json:"page"`
+		PerPage int           `json:"perPage"`
+		Total   int           `json:"total"`
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &page)
+	if page.Total != 11 || page.Page != 3 || page.PerPage != 5 || len(page.Items) != 1 {
+		t.Errorf("Not the right page: %s", recorder.Body.String())
+	}
+}
+
+func TestV1GetLegalActionsShouldRejectAnInvalidPage(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	//When
+	recorder := serveWith(router, "GET", "/v1/games/"+g.ID+"/legal-actions?perPage=1000", "", nil)
+	//Then
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("The status should be 400: %v", recorder.Code)
+	}
+	var e response.Error
+	json.Unmarshal(recorder.Body.Bytes(), &e)
+	if e.Code != "invalid_request" {
+		t.Errorf("Not the right error: %v", e)
+	}
+}
+
+func TestLegacyRoutesShouldStillWork(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	//When
+	recorder := serveWith(router, "GET", "/games/"+g.ID+"/move-pawn/possibilities", "", nil)
+	//Then
+	if recorder.Code != http.StatusOK {
+		t.Errorf("The status should be 200: %v", recorder.Code)
+	}
+}
+
+func TestV1GetGameShouldRenderTheTextBoardWithTheOptions(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	created := createV1Game(t, router, `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`)
+	header := http.Header{"Accept": []string{"text/plain"}}
+	//When
+	w := serveWith(router, "GET", "/v1/games/"+created.ID+"?coordinates=true&ascii=true&fencesLeft=true", "", header)
+	invalid := serveWith(router, "GET", "/v1/games/"+created.ID+"?color=maybe", "", header)
+	//Then
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "   a   b") || !strings.Contains(w.Body.String(), "1: 3 fences left to play") {
+		t.Errorf("The board should be rendered with the options: %v\n%v", w.Code, w.Body.String())
+	}
+	if invalid.Code != http.StatusBadRequest {
+		t.Errorf("The option should be rejected: %v", invalid.Code)
+	}
+}
+
+func TestV1GetGameShouldDrawTheBoardAsAnImage(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	created := createV1Game(t, router, `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`)
+	//When
+	svg := serveWith(router, "GET", "/v1/games/"+created.ID, "", http.Header{"Accept": []string{"image/svg+xml"}})
+	image := serveWith(router, "GET", "/v1/games/"+created.ID, "", http.Header{"Accept": []string{"image/webp, image/png;q=0.9"}})
+	//Then
+	if svg.Code != http.StatusOK || svg.Header().Get("content-type") != "image/svg+xml" || !strings.HasPrefix(svg.Body.String(), "<svg") {
+		t.Errorf("The board should be drawn as SVG: %v %v", svg.Code, svg.Header())
+	}
+	if image.Code != http.StatusOK || image.Header().Get("content-type") != "image/png" || !strings.HasPrefix(image.Body.String(), "\x89PNG") {
+		t.Errorf("The board should be drawn as PNG: %v %v", image.Code, image.Header())
+	}
+}
+
+func TestReplayShouldExportTheGame(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	created := createV1Game(t, router, `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`)
+	//When
+	animation := serveWith(router, "GET", "/v1/games/"+created.ID+"/replay?delay=100", "", nil)
+	page := serveWith(router, "GET", "/games/"+created.ID+"/replay", "", http.Header{"Accept": []string{"text/html"}})
+	invalid := serveWith(router, "GET", "/v1/games/"+created.ID+"/replay?delay=5", "", nil)
+	//Then
+	if animation.Code != http.StatusOK || !strings.HasPrefix(animation.Body.String(), "GIF89a") {
+		t.Errorf("The replay should be a GIF: %v", animation.Code)
+	}
+	if page.Code != http.StatusOK || !strings.HasPrefix(page.Body.String(), "<!DOCTYPE html>") {
+		t.Errorf("The replay should be a page: %v", page.Code)
+	}
+	if invalid.Code != http.StatusBadRequest {
+		t.Errorf("The delay should be rejected: %v", invalid.Code)
+	}
+}
+
+func TestV1GetGameShouldReplayTheGameUntilThePly(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	first := joinV1Game(router, g.ID)
+	joinV1Game(router, g.ID)
+	serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 4}}`, http.Header{"Authorization": []string{first.Token}})
+	//When
+	initial := serveWith(router, "GET", "/v1/games/"+g.ID+"?ply=0", "", nil)
+	missing := serveWith(router, "GET", "/v1/games/"+g.ID+"?ply=2", "", nil)
+	//Then
+	var resource server.GameResource
+	json.Unmarshal(initial.Body.Bytes(), &resource)
+	if resource.CurrentPlayer != 1 || resource.Players[0].Position.Column != 0 {
+		t.Errorf("The game should be at its start: %s", initial.Body.String())
+	}
+	if missing.Code != http.StatusNotFound {
+		t.Errorf("The ply should not exist: %v", missing.Code)
+	}
+}
+
+func TestPositionsShouldListEveryPosition(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	first := joinV1Game(router, g.ID)
+	joinV1Game(router, g.ID)
+	serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 4}}`, http.Header{"Authorization": []string{first.Token}})
+	//When
+	recorder := serveWith(router, "GET", "/games/"+g.ID+"/positions", "", nil)
+	//Then
+	var page struct {
+		Items []game.Game `json:"items"`
+		Total int         `json:"total"`
+	}
+	json.Unmarshal(recorder.Body.Bytes(), &page)
+	if page.Total != 2 || page.Items[1].Pawns[0].Position != (game.Position{1, 4}) || len(page.Items[1].History) != 1 {
+		t.Errorf("Not the right positions: %s", recorder.Body.String())
+	}
+}
+
+func TestForkShouldCreateANewGameAndSeatTheRequester(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	g := createV1Game(t, router, "")
+	first := joinV1Game(router, g.ID)
+	joinV1Game(router, g.ID)
+	serveWith(router, "POST", "/v1/games/"+g.ID+"/actions", `{"type": "move", "position": {"column": 1, "row": 4}}`, http.Header{"Authorization": []string{first.Token}})
+	//When
+	recorder := serveWith(router, "POST", "/v1/games/"+g.ID+"/fork?ply=0", "", nil)
+	//Then
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("The status should be 201: %v %s", recorder.Code, recorder.Body.String())
+	}
+	var seat server.GameSeat
+	json.Unmarshal(recorder.Body.Bytes(), &seat)
+	fork := serveWith(router, "GET", "/v1/games/"+seat.GameID, "", nil)
+	var resource server.GameResource
+	json.Unmarshal(fork.Body.Bytes(), &resource)
+	if seat.Number != 1 || resource.Players[0].Position.Column != 0 || !resource.Players[0].Seated || resource.ForkedFrom == nil || resource.ForkedFrom.GameID != g.ID {
+		t.Errorf("Not the right fork: %s %s", recorder.Body.String(), fork.Body.String())
+	}
+}
+
+func TestOpeningsShouldExploreTheBook(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	positions, _ := game.Replay(game.Configuration{BoardSize: 7, NumberOfFencesPerPawnPlayer: 4}, "id", []game.PlayedAction{{1, game.NewMoveAction(game.Position{1, 3}), nil}, {2, game.NewMoveAction(game.Position{5, 3}), nil}})
+	openings.Default.Add(positions, 1)
+	next, _ := openings.Hash(positions[1])
+	//When
+	starts := serveWith(router, "GET", "/v1/openings", "", nil)
+	position := serveWith(router, "GET", "/openings?position="+next, "", nil)
+	unknown := serveWith(router, "GET", "/v1/openings?position=unknown", "", nil)
+	//Then
+	var page struct {
+		Items []openings.Opening `json:"items"`
+		Total int                `json:"total"`
+	}
+	json.Unmarshal(starts.Body.Bytes(), &page)
+	if starts.Code != http.StatusOK || page.Total == 0 {
+		t.Errorf("The starting positions should be listed: %s", starts.Body.String())
+	}
+	json.Unmarshal(position.Body.Bytes(), &page)
+	if page.Total != 1 || page.Items[0].Position != next || page.Items[0].Ply != 1 {
+		t.Errorf("Not the right position: %s", position.Body.String())
+	}
+	json.Unmarshal(unknown.Body.Bytes(), &page)
+	if page.Total != 0 {
+		t.Errorf("The position should not be in the book: %s", unknown.Body.String())
+	}
+}
+
+func TestRecordOpeningShouldLeaveTheForksOut(t *testing.T) {
+	//Given
+	newRouter(t)
+	g, _ := gamecontroller.CreateGame(game.Configuration{BoardSize: 5, NumberOfFencesPerPawnPlayer: 3})
+	gamecontroller.JoinGame(g.ID, "azerty")
+	gamecontroller.JoinGame(g.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(g.ID, game.NewMoveAction(game.Position{1, 2}), 1)
+	fork, _ := gamecontroller.ForkGame(g.ID, 1, "")
+	gamecontroller.JoinGame(fork.ID, "azerty")
+	gamecontroller.JoinGame(fork.ID, "qsdfgh")
+	gamecontroller.PlayActionAs(fork.ID, game.NewMoveAction(game.Position{3, 2}), 2)
+	gamecontroller.Resign(fork.ID, 1)
+	//When
+	server.RecordOpening(gamecontroller.GameResult{fork.ID, 5, 2, []string{"", ""}})
+	//Then
+	hash, _ := openings.Hash(*fork)
+	if opening, found := openings.Default.Get(hash); found {
+		t.Errorf("The fork should not be recorded: %v", opening)
+	}
+}
+
+func TestSolutionShouldSolveTheRaceOnceNoFencesAreLeft(t *testing.T) {
+	//Given
+	router := newRouter(t)
+	race := createV1Game(t, router, `{"boardSize": 5, "numberOfFencesPerPlayer": 0}`)
+	fences := createV1Game(t, router, `{"boardSize": 5, "numberOfFencesPerPlayer": 3}`)
+	//When
+	recorder := serveWith(router, "GET", "/v1/games/"+race.ID+"/solution", "", nil)
+	conflict := serveWith(router, "GET", "/games/"+fences.ID+"/solution", "", nil)
+	//Then
+	var solution game.Solution
+	json.Unmarshal(recorder.Body.Bytes(), &solution)
+	if recorder.Code != http.StatusOK || solution.Action == nil {
+		t.Errorf("The race should be solved: %v %s", recorder.Code, recorder.Body.String())
+	}
+	if conflict.Code != http.StatusConflict {
+		t.Errorf("The game should not be solved with fences left: %v", conflict.Code)
+	}
+}
@@ -0,0 +1,229 @@
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"quoridor/controller"
+	"quoridor/exception"
+	"quoridor/game"
+	"quoridor/storage"
+	"quoridor/tournament"
+)
+
+var smallBoard = game.Configuration{BoardSize: 3, NumberOfFencesPerPawnPlayer: 0}
+
+func newTournament(t *testing.T, format tournament.Format, names ...string) (tournament.Tournament, []tournament.Registration) {
+	storage.Init()
+	created, err := tournament.Create(tournament.Settings{"ladder", format, 0, smallBoard, 0})
+	if err != nil {
+		t.Fatalf("The tournament should be created: %s", err.Error())
+	}
+	registrations := []tournament.Registration{}
+	for _, name := range names {
+		registration, _ := tournament.Register(created.ID, name, "")
+		registrations = append(registrations, registration)
+	}
+	started, err := tournament.Start(created.ID, created.OrganizerToken)
+	if err != nil {
+		t.Fatalf("The tournament should start: %s", err.Error())
+	}
+	return started, registrations
+}
+
+// playRound make the first participant of each pairing win
+func playRound(t tournament.Tournament) tournament.Tournament {
+	for _, pairing := range t.Rounds[len(t.Rounds)-1].Pairings {
+		if pairing.GameID != "" {
+			tournament.Record(gamecontroller.GameResult{pairing.GameID, 3, 1, []string{"", ""}})
+		}
+	}
+	updated, _ := tournament.Get(t.ID)
+	return updated
+}
+
+func TestRoundRobinShouldPairEveryParticipantWithEveryOther(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob", "carol", "dave")
+	//When
+	finished := started
+	for finished.Status == tournament.RUNNING {
+		finished = playRound(finished)
+	}
+	//Then
+	if finished.NumberOfRounds != 3 || len(finished.Rounds) != 3 {
+		t.Errorf("There should be 3 rounds: %v", finished.Rounds)
+	}
+	met := make(map[string]int)
+	for _, round := range finished.Rounds {
+		for _, pairing := range round.Pairings {
+			met[pairing.First+pairing.Second]++
+			met[pairing.Second+pairing.First]++
+		}
+	}
+	for _, first := range finished.Participants {
+		for _, second := range finished.Participants {
+			if first.ID != second.ID && met[first.ID+second.ID] != 1 {
+				t.Errorf("%v and %v should meet once", first.Name, second.Name)
+			}
+		}
+	}
+}
+
+func TestRoundRobinShouldGiveAByeToEachParticipantWhenOdd(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob", "carol")
+	//When
+	finished := started
+	for finished.Status == tournament.RUNNING {
+		finished = playRound(finished)
+	}
+	//Then
+	standings, _ := tournament.GetStandings(finished.ID)
+	for _, standing := range standings {
+		if standing.Byes != 1 {
+			t.Errorf("Each participant should have a bye: %v", standing)
+		}
+	}
+}
+
+func TestSwissShouldPairTheWinnersTogether(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.SWISS, "alice", "bob", "carol", "dave")
+	firstRound := started.Rounds[0].Pairings
+	//When
+	second := playRound(started)
+	//Then
+	if second.NumberOfRounds != 2 || len(second.Rounds) != 2 {
+		t.Errorf("There should be 2 rounds: %v", second.Rounds)
+		return
+	}
+	winners := map[string]bool{firstRound[0].First: true, firstRound[1].First: true}
+	for _, pairing := range second.Rounds[1].Pairings {
+		if winners[pairing.First] != winners[pairing.Second] {
+			t.Errorf("The winners should meet: %v", second.Rounds[1].Pairings)
+		}
+	}
+}
+
+func TestStandingsShouldBreakTiesWithBuchholz(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.SWISS, "alice", "bob", "carol", "dave")
+	//When
+	finished := playRound(playRound(started))
+	//Then
+	standings, _ := tournament.GetStandings(finished.ID)
+	if finished.Status != tournament.FINISHED || standings[0].Points != 2 || standings[3].Points != 0 {
+		t.Errorf("Not the right standings: %v", standings)
+		return
+	}
+	if standings[1].Points != standings[2].Points || standings[1].Buchholz < standings[2].Buchholz || standings[1].Rank != 2 {
+		t.Errorf("The tie should be broken by the Buchholz score: %v", standings)
+	}
+}
+
+func TestRegisterShouldBeClosedOnceStarted(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.SWISS, "alice", "bob")
+	//When
+	_, err := tournament.Register(started.ID, "carol", "")
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The registrations should be closed: %v", err)
+	}
+}
+
+func TestStartShouldRequireTwoParticipants(t *testing.T) {
+	//Given
+	storage.Init()
+	created, _ := tournament.Create(tournament.Settings{"ladder", tournament.SWISS, 0, smallBoard, 0})
+	tournament.Register(created.ID, "alice", "")
+	//When
+	_, err := tournament.Start(created.ID, created.OrganizerToken)
+	//Then
+	if err == nil {
+		t.Error("The tournament should not start")
+	}
+}
+
+func TestStartShouldRequireTheOrganizerToken(t *testing.T) {
+	//Given
+	storage.Init()
+	created, _ := tournament.Create(tournament.Settings{"ladder", tournament.SWISS, 0, smallBoard, 0})
+	tournament.Register(created.ID, "alice", "")
+	tournament.Register(created.ID, "bob", "")
+	//When
+	_, err := tournament.Start(created.ID, "participant")
+	//Then
+	if !exception.MatchGameError(err, exception.UNAUTHORIZED) {
+		t.Errorf("Only the organizer should start the tournament: %v", err)
+	}
+}
+
+func TestCreateShouldRejectAnInvalidConfiguration(t *testing.T) {
+	//Given
+	storage.Init()
+	//When
+	_, err := tournament.Create(tournament.Settings{"ladder", tournament.SWISS, 0, game.Configuration{BoardSize: 8}, 0})
+	//Then
+	if err == nil {
+		t.Error("The configuration should be rejected")
+	}
+}
+
+func TestGetSeatShouldGiveTheGameOfTheParticipant(t *testing.T) {
+	//Given
+	started, registrations := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob")
+	//When
+	gameID, number, err := tournament.GetSeat(started.ID, registrations[1].Token)
+	//Then
+	pairing := started.Rounds[0].Pairings[0]
+	expected := 1
+	if pairing.Second == registrations[1].Participant.ID {
+		expected = 2
+	}
+	if err != nil || gameID != pairing.GameID || number != expected {
+		t.Errorf("Not the right seat: %v %v %v", gameID, number, err)
+	}
+}
+
+func TestTournamentShouldCollectTheResultsOfThePlayedGames(t *testing.T) {
+	//Given
+	tournament.Init()
+	started, _ := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob")
+	gameID := started.Rounds[0].Pairings[0].GameID
+	gamecontroller.PlayActionAs(gameID, game.NewMoveAction(game.Position{1, 1}), 1)
+	//When
+	gamecontroller.PlayActionAs(gameID, game.NewMoveAction(game.Position{0, 1}), 2)
+	//Then
+	finished, _ := tournament.Get(started.ID)
+	if finished.Status != tournament.FINISHED || finished.Rounds[0].Pairings[0].Winner != started.Rounds[0].Pairings[0].Second {
+		t.Errorf("The second player should win the tournament: %v", finished)
+	}
+}
+
+func TestSuperviseShouldForfeitTheGamesPastTheDeadline(t *testing.T) {
+	//Given
+	tournament.Init()
+	started, _ := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob")
+	pairing := started.Rounds[0].Pairings[0]
+	//When
+	tournament.Supervise(started.Rounds[0].Deadline.Add(time.Second))
+	//Then
+	finished, _ := tournament.Get(started.ID)
+	if finished.Status != tournament.FINISHED || finished.Rounds[0].Pairings[0].Winner != pairing.Second {
+		t.Errorf("The first player should forfeit as the pawn to play: %v", finished)
+	}
+}
+
+func TestSuperviseShouldWaitForTheDeadline(t *testing.T) {
+	//Given
+	started, _ := newTournament(t, tournament.ROUND_ROBIN, "alice", "bob")
+	//When
+	tournament.Supervise(started.Rounds[0].Deadline.Add(-time.Second))
+	//Then
+	g, _ := gamecontroller.GetGame(started.Rounds[0].Pairings[0].GameID)
+	if g.Over {
+		t.Error("The game should still be playing")
+	}
+}
@@ -0,0 +1,89 @@
+package users
+
+import (
+	"testing"
+
+	"quoridor/exception"
+	"quoridor/storage"
+	"quoridor/users"
+)
+
+func setUp() {
+	storage.Init()
+}
+
+func TestRegisterShouldCreateAUser(t *testing.T) {
+	//Given
+	setUp()
+	//When
+	user, err := users.Register(users.Credentials{"alice", "correct horse"})
+	//Then
+	if err != nil || user.ID == "" || user.Name != "alice" {
+		t.Errorf("The user should be created: %v %v", user, err)
+	}
+}
+
+func TestRegisterShouldRejectATakenName(t *testing.T) {
+	//Given
+	setUp()
+	users.Register(users.Credentials{"alice", "correct horse"})
+	//When
+	_, err := users.Register(users.Credentials{"Alice", "battery staple"})
+	//Then
+	if !exception.MatchGameError(err, exception.CONFLICT) {
+		t.Errorf("The name should be taken: %v", err)
+	}
+}
+
+func TestRegisterShouldRejectAShortPassword(t *testing.T) {
+	//Given
+	setUp()
+	//When
+	_, err := users.Register(users.Credentials{"alice", "short"})
+	//Then
+	if !exception.MatchGameError(err, exception.INVALID_REQUEST) {
+		t.Errorf("The password should be rejected: %v", err)
+	}
+}
+
+func TestLoginShouldOpenASession(t *testing.T) {
+	//Given
+	setUp()
+	user, _ := users.Register(users.Credentials{"alice", "correct horse"})
+	//When
+	session, err := users.Login(users.Credentials{"alice", "correct horse"})
+	//Then
+	if err != nil {
+		t.Errorf("The user should be logged in: %s", err.Error())
+		return
+	}
+	authenticated, err := users.Authenticate(session.Token)
+	if err != nil || authenticated.ID != user.ID {
+		t.Errorf("The session should authenticate the user: %v %v", authenticated, err)
+	}
+}
+
+func TestLoginShouldRejectAWrongPassword(t *testing.T) {
+	//Given
+	setUp()
+	users.Register(users.Credentials{"alice", "correct horse"})
+	//When
+	_, err := users.Login(users.Credentials{"alice", "battery staple"})
+	//Then
+	if !exception.MatchGameError(err, exception.UNAUTHORIZED) {
+		t.Errorf("The password should be rejected: %v", err)
+	}
+}
+
+func TestLogoutShouldCloseTheSession(t *testing.T) {
+	//Given
+	setUp()
+	users.Register(users.Credentials{"alice", "correct horse"})
+	session, _ := users.Login(users.Credentials{"alice", "correct horse"})
+	//When
+	users.Logout(session.Token)
+	//Then
+	if _, err := users.Authenticate(session.Token); err == nil {
+		t.Error("The session should be closed")
+	}
+}